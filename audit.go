@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// auditLimit is how many of the most recent audit entries are shown.
+const auditLimit = 500
+
+// openAudit loads the most recent audit entries and shows them.
+func (m model) openAudit() (tea.Model, tea.Cmd) {
+	entries, err := m.store.AuditLog(auditLimit)
+	if err != nil {
+		m.err = fmt.Errorf(tr("failed to load audit log: %v"), err)
+		return m, nil
+	}
+
+	m.audit = entries
+	m.auditCursor = 0
+	m.inputmode = viewingAudit
+
+	return m, nil
+}
+
+func (m model) updateAudit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "esc", "A":
+		m.inputmode = normal
+		m.audit = nil
+
+	case "up", "k":
+		if m.auditCursor > 0 {
+			m.auditCursor--
+		}
+
+	case "down", "j":
+		if m.auditCursor < len(m.audit)-1 {
+			m.auditCursor++
+		}
+
+	case "pgup", "[":
+		m.auditCursor = max(m.auditCursor-m.rowsPerPage(), 0)
+
+	case "pgdown", "]":
+		m.auditCursor = max(min(m.auditCursor+m.rowsPerPage(), len(m.audit)-1), 0)
+	}
+
+	return m, nil
+}
+
+// auditView renders a page of audit entries, newest first, followed by
+// the snapshot of the selected one.
+func (m model) auditView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.title.Render(tr("Audit Log")))
+	b.WriteString("\n")
+
+	if len(m.audit) == 0 {
+		b.WriteString(m.theme.help().Render(tr("Nothing has been changed yet")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	b.WriteString(m.theme.title.Render(fmt.Sprintf("%-16s | %-7s | %6s | %-12s | %s", tr("When"), tr("Action"), tr("Item"), tr("User"), tr("Name"))))
+	b.WriteString("\n")
+
+	// Leave room for the snapshot below the list.
+	perPage := max(m.rowsPerPage()-20, 5)
+	start := m.auditCursor / perPage * perPage
+	end := min(start+perPage, len(m.audit))
+
+	for i := start; i < end; i++ {
+		e := m.audit[i]
+
+		var item store.Item
+		json.Unmarshal([]byte(e.Snapshot), &item)
+
+		line := fmt.Sprintf("%-16s | %-7s | %6d | %-12s | %s",
+			e.At.Local().Format("2006-01-02 15:04"), e.Action, e.ItemID, fit(e.Actor, 12), item.Name)
+
+		if i == m.auditCursor {
+			b.WriteString(m.theme.selected.Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	if pages := (len(m.audit) + perPage - 1) / perPage; pages > 1 {
+		b.WriteString(m.theme.help().Render(tr("Page %d of %d", start/perPage+1, pages)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.theme.label.Render(tr("Snapshot")))
+	b.WriteString("\n")
+	snapshot := m.audit[m.auditCursor].Snapshot
+	var indented bytes.Buffer
+	if json.Indent(&indented, []byte(snapshot), "", "  ") == nil {
+		snapshot = indented.String()
+	}
+	b.WriteString(m.theme.detail.Render(snapshot))
+	b.WriteString("\n")
+
+	return b.String()
+}
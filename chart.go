@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// barEighths are the block characters for a bar's last cell, by how many
+// eighths of it are filled.
+var barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+func (m model) updateChart(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc", "enter", "C":
+		m.inputmode = normal
+	}
+
+	return m, nil
+}
+
+// chartView draws a horizontal bar per waste type and unit, scaled so that
+// the largest total fills the width of the terminal.
+func (m model) chartView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.title.Render(tr("Quantity by Type")))
+	b.WriteString("\n")
+
+	totals := typeTotals(m.displayAll(m.waste))
+	if len(totals) == 0 {
+		b.WriteString(m.theme.help().Render(tr("There are no items to chart")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	labels := make([]string, len(totals))
+	values := make([]string, len(totals))
+	labelWidth, valueWidth := 0, 0
+	largest := 0.0
+
+	for i, t := range totals {
+		labels[i] = t.wasteType
+		if labels[i] == "" {
+			labels[i] = tr("(no type)")
+		}
+		values[i] = strings.TrimSpace(m.formatQuantity(t.quantity) + " " + t.unit)
+
+		labelWidth = max(labelWidth, len([]rune(labels[i])))
+		valueWidth = max(valueWidth, len([]rune(values[i])))
+		largest = max(largest, t.quantity)
+	}
+	labelWidth = min(labelWidth, 20)
+
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+	barWidth := max(width-labelWidth-valueWidth-4, 10)
+
+	for i, t := range totals {
+		eighths := 0
+		if largest > 0 {
+			eighths = int(t.quantity / largest * float64(barWidth*8))
+		}
+		bar := strings.Repeat("█", eighths/8) + barEighths[eighths%8]
+
+		fmt.Fprintf(&b, "%s %s %s\n",
+			fit(labels[i], labelWidth),
+			m.theme.typeStyle(t.wasteType).Render(fit(bar, barWidth)),
+			values[i])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// runCommand runs the subcommand in args against s without starting the
+// TUI.
+func runCommand(s store.Store, args []string) error {
+	var err error
+
+	switch args[0] {
+	case "add":
+		err = addCommand(s, args[1:])
+	case "list":
+		err = listCommand(s, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q, expected add or list", args[0])
+	}
+
+	// The flag set has already printed the usage.
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+
+	return err
+}
+
+// addCommand adds one item given by flags, checked the same way as the
+// form checks it.
+func addCommand(s store.Store, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	name := fs.String("name", "", "name of the item (required)")
+	quantity := fs.String("quantity", "", "quantity, greater than zero (required)")
+	unit := fs.String("unit", "", "unit of the quantity, such as kg, l or pcs")
+	wasteType := fs.String("type", "", "waste type (required)")
+	location := fs.String("location", "", "where the item is kept")
+	method := fs.String("method", "", "disposal method")
+	disposalDate := fs.String("dispose-by", "", "disposal date as YYYY-MM-DD")
+	hazardous := fs.Bool("hazardous", false, "mark the item as hazardous")
+	notes := fs.String("notes", "", "free text notes")
+	cost := fs.String("cost", "", "disposal cost")
+	status := fs.String("status", store.StatusCollected, "one of "+strings.Join(store.Statuses, ", "))
+	frequency := fs.String("frequency", "", "for recurring items, one of "+strings.Join(store.Frequencies, ", "))
+	tags := fs.String("tags", "", "comma-separated tags")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	item := store.Item{
+		Name:         strings.TrimSpace(*name),
+		Unit:         strings.TrimSpace(*unit),
+		WasteType:    strings.TrimSpace(*wasteType),
+		Location:     strings.TrimSpace(*location),
+		Method:       strings.TrimSpace(*method),
+		DisposalDate: strings.TrimSpace(*disposalDate),
+		Hazardous:    *hazardous,
+		Notes:        strings.TrimSpace(*notes),
+		Status:       *status,
+		Frequency:    strings.ToLower(strings.TrimSpace(*frequency)),
+		Tags:         store.ParseTags(*tags),
+	}
+
+	if item.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	q, err := parseQuantity(*quantity)
+	if err != nil {
+		return err
+	}
+	item.Quantity = q
+
+	if item.WasteType == "" {
+		return fmt.Errorf("waste type is required")
+	}
+
+	if item.DisposalDate != "" {
+		if _, err := time.Parse(store.DateLayout, item.DisposalDate); err != nil {
+			return fmt.Errorf("disposal date must be YYYY-MM-DD")
+		}
+	}
+
+	item.Cost, err = parseCost(*cost)
+	if err != nil {
+		return err
+	}
+
+	if !store.ValidStatus(item.Status) {
+		return fmt.Errorf("status must be one of %s", strings.Join(store.Statuses, ", "))
+	}
+
+	if !store.ValidFrequency(item.Frequency) {
+		return fmt.Errorf("frequency must be one of %s", strings.Join(store.Frequencies, ", "))
+	}
+
+	item, err = s.Add(item)
+	if err != nil {
+		return fmt.Errorf("failed to add item: %v", err)
+	}
+
+	fmt.Printf("Added '%s' with id %d\n", item.Name, item.ID)
+	return nil
+}
+
+// listCommand prints every item as a plain table.
+func listCommand(s store.Store, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	items, err := s.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load items: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tName\tType\tQuantity\tUnit\tLocation\tDisposal Method\tCreated\tDispose By\tStatus\tHazardous\tCost")
+
+	for _, item := range items {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%.2f\n",
+			item.ID, item.Name, item.WasteType, strconv.FormatFloat(item.Quantity, 'f', 2, 64), item.Unit,
+			item.Location, item.Method, item.CreatedAt.Format(store.DateLayout), item.DisposalDate, item.Status,
+			englishYesNo(item.Hazardous), item.Cost)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// noClipboard is the error shown when there is no clipboard to copy to,
+// such as over SSH or on Linux without xclip, xsel or wl-clipboard
+// installed. It is translated when it is shown, so it is not an error value.
+const noClipboard = "no clipboard is available here; on Linux, install xclip, xsel or wl-clipboard"
+
+// copyItem copies the selected item to the clipboard, as a line of text or
+// as indented JSON.
+func (m model) copyItem(asJSON bool) error {
+	item := m.waste[m.current()]
+
+	text := m.itemLine(item)
+	if asJSON {
+		data, err := json.MarshalIndent(item, "", "  ")
+		if err != nil {
+			return err
+		}
+		text = string(data)
+	}
+
+	if clipboard.Unsupported {
+		return errors.New(tr(noClipboard))
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		return errors.New(tr(noClipboard))
+	}
+
+	return nil
+}
+
+// itemLine describes item on one line, leaving out the fields it has no
+// value for.
+func (m model) itemLine(item store.Item) string {
+	parts := []string{fmt.Sprintf("%s: %s", item.Name, strings.TrimSpace(m.formatQuantity(item.Quantity)+" "+item.Unit))}
+
+	if item.WasteType != "" {
+		parts = append(parts, item.WasteType)
+	}
+	if item.Location != "" {
+		parts = append(parts, "at "+item.Location)
+	}
+	if item.Method != "" {
+		parts = append(parts, "by "+item.Method)
+	}
+	if item.DisposalDate != "" {
+		parts = append(parts, "dispose by "+item.DisposalDate)
+	}
+	if item.Hazardous {
+		parts = append(parts, "hazardous")
+	}
+	parts = append(parts, item.Status)
+
+	return strings.Join(parts, ", ")
+}
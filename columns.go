@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// columnByName returns the index into columnTitles of the column saved
+// under name, which is its sort name, or -1 if there is none.
+func columnByName(name string) int {
+	for i := range columnTitles {
+		if sortNames[i+1] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// hiddenColumnNames returns the names of the hidden columns, comma
+// separated, for saving.
+func (m model) hiddenColumnNames() string {
+	var names []string
+	for i := range columnTitles {
+		if m.hiddenColumns[i] {
+			names = append(names, sortNames[i+1])
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+func (m model) updateColumns(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "esc", "v":
+		m.inputmode = normal
+
+	case "up", "k":
+		if m.columnCursor > 0 {
+			m.columnCursor--
+		}
+
+	case "down", "j":
+		if m.columnCursor < len(columnTitles)-1 {
+			m.columnCursor++
+		}
+
+	case " ", "enter":
+		m.err = nil
+		if m.hiddenColumns == nil {
+			m.hiddenColumns = make(map[int]bool)
+		}
+
+		if m.hiddenColumns[m.columnCursor] {
+			delete(m.hiddenColumns, m.columnCursor)
+		} else if len(m.hiddenColumns) < len(columnTitles)-1 {
+			m.hiddenColumns[m.columnCursor] = true
+		} else {
+			m.err = errors.New(tr("at least one column must be shown"))
+		}
+	}
+
+	return m, nil
+}
+
+// columnsView renders the menu of columns with whether each is shown.
+func (m model) columnsView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.title.Render(tr("Columns")))
+	b.WriteString("\n")
+
+	for i, title := range columnTitles {
+		check := "[x]"
+		if m.hiddenColumns[i] {
+			check = "[ ]"
+		}
+
+		line := check + " " + tr(title)
+		if i == m.columnCursor {
+			b.WriteString(m.theme.selected.Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	if m.err != nil {
+		b.WriteString(m.theme.err.Render(tr("Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
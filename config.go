@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// config holds the settings that can be given in the config file. Zero
+// values mean the built-in default.
+type config struct {
+	db       string
+	pageSize int
+	theme    string
+	sort     string
+	sortDesc bool
+	currency string
+
+	// wrapNavigation makes up and down wrap around the ends of the list.
+	wrapNavigation bool
+
+	// noHints turns off the key hints under the title.
+	noHints bool
+
+	// precision is nil unless set, as zero decimal places is a valid
+	// choice and not the default.
+	precision *int
+
+	// undoDepth is nil unless set, as zero turns undo off.
+	undoDepth *int
+
+	// step is how much +/- change a quantity by, and unitSteps the step
+	// for items in the units the file sets one for, keyed in lower case.
+	step      float64
+	unitSteps map[string]float64
+
+	// charLimits maps form input indices to the most characters the
+	// input takes, for those the file sets.
+	charLimits map[int]int
+
+	// locations are the only locations items may be given in the form,
+	// unless there are none.
+	locations []string
+
+	// defaults maps form input indices to the value the input starts
+	// with when adding an item, for those the file sets.
+	defaults map[int]string
+
+	// noColor is set by -no-color or $NO_COLOR rather than the file, and
+	// forces the plain theme.
+	noColor bool
+
+	// refresh is how often the items are reloaded, or zero for never.
+	refresh time.Duration
+
+	// readOnly is set by -read-only rather than the file.
+	readOnly bool
+}
+
+// defaultPrecision and maxPrecision are the default and largest number of
+// decimal places quantities are shown with.
+const (
+	defaultPrecision = 2
+	maxPrecision     = 6
+)
+
+// defaultConfigPath returns config.toml in the user's config directory,
+// which is $XDG_CONFIG_HOME/wmtui on Linux, or an empty string if there is
+// none.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "wmtui", "config.toml")
+}
+
+// loadConfig reads the config file at path. A missing file gives the
+// defaults unless required is set.
+//
+// Only the flat subset of TOML the settings need is understood: one
+// key = value per line, where the value is a quoted string, a list of them
+// on the one line, an integer or a boolean, and # starts a comment.
+func loadConfig(path string, required bool) (config, error) {
+	var cfg config
+
+	if path == "" {
+		return cfg, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return cfg, fmt.Errorf("%s:%d: expected key = value", path, n)
+		}
+
+		if err := cfg.set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return cfg, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+	}
+
+	return cfg, scanner.Err()
+}
+
+// set parses value and stores it under key.
+func (cfg *config) set(key, value string) error {
+	if name, ok := strings.CutPrefix(key, "char_limit_"); ok {
+		return cfg.setCharLimit(name, value)
+	}
+	if name, ok := strings.CutPrefix(key, "default_"); ok {
+		return cfg.setDefault(name, value)
+	}
+	if unit, ok := strings.CutPrefix(key, "quantity_step_"); ok {
+		step, err := parseStep(key, value)
+		if err != nil {
+			return err
+		}
+		if cfg.unitSteps == nil {
+			cfg.unitSteps = make(map[string]float64)
+		}
+		cfg.unitSteps[strings.ToLower(unit)] = step
+		return nil
+	}
+
+	switch key {
+	case "db":
+		return parseString(value, &cfg.db)
+
+	case "currency":
+		return parseString(value, &cfg.currency)
+
+	case "locations":
+		locations, err := parseStrings(value)
+		if err != nil {
+			return err
+		}
+		for _, location := range locations {
+			if strings.TrimSpace(location) == "" {
+				return fmt.Errorf("locations cannot be blank")
+			}
+		}
+		cfg.locations = locations
+
+	case "theme":
+		if err := parseString(value, &cfg.theme); err != nil {
+			return err
+		}
+		if _, ok := themeByName(cfg.theme); !ok {
+			return fmt.Errorf("unknown theme %q", cfg.theme)
+		}
+
+	case "sort":
+		if err := parseString(value, &cfg.sort); err != nil {
+			return err
+		}
+		for _, name := range sortNames {
+			if name == cfg.sort {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown sort column %q", cfg.sort)
+
+	case "page_size":
+		n, err := strconv.Atoi(stripComment(value))
+		if err != nil || n < 0 {
+			return fmt.Errorf("page_size must be a whole number")
+		}
+		cfg.pageSize = n
+
+	case "precision":
+		n, err := strconv.Atoi(stripComment(value))
+		if err != nil || n < 0 || n > maxPrecision {
+			return fmt.Errorf("precision must be a whole number from 0 to %d", maxPrecision)
+		}
+		cfg.precision = &n
+
+	case "refresh":
+		var s string
+		if err := parseString(value, &s); err != nil {
+			return err
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil || validRefresh(d) != nil {
+			return fmt.Errorf("refresh must be a duration of at least %v, such as \"30s\", or \"0s\" to turn it off", minRefresh)
+		}
+		cfg.refresh = d
+
+	case "wrap_navigation":
+		b, err := strconv.ParseBool(stripComment(value))
+		if err != nil {
+			return fmt.Errorf("wrap_navigation must be true or false")
+		}
+		cfg.wrapNavigation = b
+
+	case "key_hints":
+		b, err := strconv.ParseBool(stripComment(value))
+		if err != nil {
+			return fmt.Errorf("key_hints must be true or false")
+		}
+		cfg.noHints = !b
+
+	case "quantity_step":
+		step, err := parseStep(key, value)
+		if err != nil {
+			return err
+		}
+		cfg.step = step
+
+	case "undo_depth":
+		n, err := strconv.Atoi(stripComment(value))
+		if err != nil || n < 0 {
+			return fmt.Errorf("undo_depth must be a whole number")
+		}
+		cfg.undoDepth = &n
+
+	case "sort_desc":
+		b, err := strconv.ParseBool(stripComment(value))
+		if err != nil {
+			return fmt.Errorf("sort_desc must be true or false")
+		}
+		cfg.sortDesc = b
+
+	default:
+		return fmt.Errorf("unknown setting %q", key)
+	}
+
+	return nil
+}
+
+// parseStep parses the quantity step set by key.
+func parseStep(key, value string) (float64, error) {
+	step, err := strconv.ParseFloat(stripComment(value), 64)
+	if err != nil || !(step > 0) || math.IsInf(step, 0) {
+		return 0, fmt.Errorf("%s must be a number greater than zero", key)
+	}
+	return step, nil
+}
+
+// setCharLimit parses the char_limit_<name> setting of the form input
+// called name.
+func (cfg *config) setCharLimit(name, value string) error {
+	for i, input := range inputNames {
+		if input != name {
+			continue
+		}
+
+		n, err := strconv.Atoi(stripComment(value))
+		if err != nil || n < 1 {
+			return fmt.Errorf("char_limit_%s must be a whole number greater than zero", name)
+		}
+
+		if cfg.charLimits == nil {
+			cfg.charLimits = make(map[int]int)
+		}
+		cfg.charLimits[i] = n
+		return nil
+	}
+
+	return fmt.Errorf("unknown setting %q", "char_limit_"+name)
+}
+
+// setDefault parses the default_<name> setting of the form input called
+// name.
+func (cfg *config) setDefault(name, value string) error {
+	for i, input := range inputNames {
+		if input != name {
+			continue
+		}
+
+		var s string
+		if err := parseString(value, &s); err != nil {
+			return err
+		}
+		if (i == inputQuantity || i == inputCost) && validateNumber(s) != nil {
+			return fmt.Errorf("default_%s must be a number", name)
+		}
+
+		if cfg.defaults == nil {
+			cfg.defaults = make(map[int]string)
+		}
+		cfg.defaults[i] = s
+		return nil
+	}
+
+	return fmt.Errorf("unknown setting %q", "default_"+name)
+}
+
+// parseString unquotes a TOML basic string, ignoring any trailing comment.
+func parseString(value string, dst *string) error {
+	if !strings.HasPrefix(value, `"`) {
+		return fmt.Errorf("expected a quoted string")
+	}
+
+	end := 1
+	for ; end < len(value) && value[end] != '"'; end++ {
+		if value[end] == '\\' {
+			end++
+		}
+	}
+	if end >= len(value) {
+		return fmt.Errorf("unterminated string")
+	}
+
+	s, err := strconv.Unquote(value[:end+1])
+	if err != nil {
+		return fmt.Errorf("invalid string: %v", err)
+	}
+
+	if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected %q after string", rest)
+	}
+
+	*dst = s
+	return nil
+}
+
+// parseStrings parses a TOML array of basic strings written on one line,
+// such as ["a", "b"], ignoring any trailing comment.
+func parseStrings(value string) ([]string, error) {
+	rest, ok := strings.CutPrefix(value, "[")
+	if !ok {
+		return nil, fmt.Errorf("expected a list of quoted strings")
+	}
+
+	var list []string
+	for {
+		rest = strings.TrimSpace(rest)
+		if after, ok := strings.CutPrefix(rest, "]"); ok {
+			if after = strings.TrimSpace(after); after != "" && !strings.HasPrefix(after, "#") {
+				return nil, fmt.Errorf("unexpected %q after list", after)
+			}
+			return list, nil
+		}
+
+		end := 1
+		for ; end < len(rest) && rest[end] != '"'; end++ {
+			if rest[end] == '\\' {
+				end++
+			}
+		}
+		if !strings.HasPrefix(rest, `"`) || end >= len(rest) {
+			return nil, fmt.Errorf("expected a list of quoted strings")
+		}
+
+		s, err := strconv.Unquote(rest[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid string: %v", err)
+		}
+		list = append(list, s)
+
+		rest = strings.TrimSpace(rest[end+1:])
+		if after, ok := strings.CutPrefix(rest, ","); ok {
+			rest = after
+		} else if !strings.HasPrefix(rest, "]") {
+			return nil, fmt.Errorf("expected , or ] after %q", s)
+		}
+	}
+}
+
+// stripComment removes a trailing comment from an unquoted value.
+func stripComment(value string) string {
+	value, _, _ = strings.Cut(value, "#")
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+const exportPath = "waste_export.csv"
+
+var csvHeader = []string{"id", "name", "quantity", "unit", "wasteType", "location", "method", "created_at", "updated_at", "disposal_date", "hazardous", "notes", "status", "cost", "frequency", "tags"}
+
+// exportCSV writes items to path with a header row of the column names.
+func exportCSV(path string, items []store.Item) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		record := []string{
+			strconv.Itoa(item.ID),
+			item.Name,
+			strconv.FormatFloat(item.Quantity, 'f', -1, 64),
+			item.Unit,
+			item.WasteType,
+			item.Location,
+			item.Method,
+			item.CreatedAt.Format(time.RFC3339),
+			item.UpdatedAt.Format(time.RFC3339),
+			item.DisposalDate,
+			strconv.FormatBool(item.Hazardous),
+			item.Notes,
+			item.Status,
+			strconv.FormatFloat(item.Cost, 'f', -1, 64),
+			item.Frequency,
+			strings.Join(item.Tags, ","),
+		}
+
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// importCSV inserts the rows of the CSV file at path in a single
+// transaction. Columns are matched by the header row if it has name and
+// quantity columns, otherwise rows are read as name, quantity, wasteType,
+// location, method, unit. Rows the add form would refuse, without a name
+// or waste type or with a quantity that is not a number above zero, are
+// skipped.
+func (m model) importCSV(path string) (imported, skipped int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	columns := map[string]int{"name": 0, "quantity": 1, "wasteType": 2, "location": 3, "method": 4, "unit": 5}
+
+	if len(records) > 0 {
+		header := make(map[string]int)
+		for i, title := range records[0] {
+			header[strings.TrimSpace(title)] = i
+		}
+
+		_, hasName := header["name"]
+		_, hasQuantity := header["quantity"]
+		if hasName && hasQuantity {
+			columns = header
+			records = records[1:]
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var items []store.Item
+
+	for _, record := range records {
+		quantity, err := parseQuantity(field(record, "quantity"))
+		if err != nil || field(record, "name") == "" || field(record, "wasteType") == "" {
+			skipped++
+			continue
+		}
+
+		items = append(items, store.Item{
+			Name:      field(record, "name"),
+			Quantity:  quantity,
+			Unit:      field(record, "unit"),
+			WasteType: field(record, "wasteType"),
+			Location:  field(record, "location"),
+			Method:    field(record, "method"),
+			Notes:     field(record, "notes"),
+			Tags:      store.ParseTags(field(record, "tags")),
+		})
+
+		if date := field(record, "disposal_date"); date != "" {
+			if _, err := time.Parse(store.DateLayout, date); err == nil {
+				items[len(items)-1].DisposalDate = date
+			}
+		}
+
+		if hazardous, err := strconv.ParseBool(field(record, "hazardous")); err == nil {
+			items[len(items)-1].Hazardous = hazardous
+		}
+
+		if status := strings.ToLower(field(record, "status")); store.ValidStatus(status) {
+			items[len(items)-1].Status = status
+		}
+
+		if cost, err := strconv.ParseFloat(field(record, "cost"), 64); err == nil && cost >= 0 {
+			items[len(items)-1].Cost = cost
+		}
+
+		if frequency := strings.ToLower(field(record, "frequency")); store.ValidFrequency(frequency) {
+			items[len(items)-1].Frequency = frequency
+		}
+	}
+
+	if _, err := m.store.AddAll(items); err != nil {
+		return 0, 0, err
+	}
+
+	return len(items), skipped, nil
+}
+
+// exportItems returns the items to export: the selected ones if there are
+// any among those shown, or else all that are shown, in their current
+// order. If all is set, it returns every item regardless.
+func (m model) exportItems(all bool) []store.Item {
+	if all {
+		return m.waste
+	}
+
+	shown := make([]store.Item, 0, len(m.filtered))
+	var selected []store.Item
+	for _, i := range m.filtered {
+		shown = append(shown, m.waste[i])
+		if m.selected[m.waste[i].ID] {
+			selected = append(selected, m.waste[i])
+		}
+	}
+
+	if len(selected) > 0 {
+		return selected
+	}
+	return shown
+}
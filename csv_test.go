@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportCSVSkipsBadRows(t *testing.T) {
+	tests := []struct {
+		name string
+		row  string
+		ok   bool
+	}{
+		{"valid", "Bottles,2.5,Plastic,Dock,Recycle,kg", true},
+		{"blank name", " ,2.5,Plastic,Dock,Recycle,kg", false},
+		{"blank type", "Bottles,2.5,,Dock,Recycle,kg", false},
+		{"blank quantity", "Bottles,,Plastic,Dock,Recycle,kg", false},
+		{"negative quantity", "Bottles,-1,Plastic,Dock,Recycle,kg", false},
+		{"zero quantity", "Bottles,0,Plastic,Dock,Recycle,kg", false},
+		{"non-numeric quantity", "Bottles,lots,Plastic,Dock,Recycle,kg", false},
+		{"NaN quantity", "Bottles,NaN,Plastic,Dock,Recycle,kg", false},
+		{"infinite quantity", "Bottles,Inf,Plastic,Dock,Recycle,kg", false},
+		{"negative infinite quantity", "Bottles,-Inf,Plastic,Dock,Recycle,kg", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t)
+
+			path := filepath.Join(t.TempDir(), "items.csv")
+			if err := os.WriteFile(path, []byte(tt.row+"\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			imported, skipped, err := m.importCSV(path)
+			if err != nil {
+				t.Fatalf("importCSV: %v", err)
+			}
+
+			want := 0
+			if tt.ok {
+				want = 1
+			}
+			if imported != want || skipped != 1-want {
+				t.Errorf("imported %d and skipped %d, want %d and %d", imported, skipped, want, 1-want)
+			}
+
+			items, err := m.store.Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if len(items) != want {
+				t.Errorf("the store has %d items, want %d", len(items), want)
+			}
+		})
+	}
+}
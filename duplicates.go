@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+func (m model) updateDuplicates(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc", "enter", "D":
+		m.inputmode = normal
+	}
+
+	return m, nil
+}
+
+// duplicateGroups returns the items that share a name, type and location
+// with another, ignoring case and surrounding spaces, in groups. Groups
+// and the items in them are in the order the items were loaded. Unlike
+// the check when adding an item, the unit is not compared, so that items
+// entered in different units are reported too.
+func (m model) duplicateGroups() [][]store.Item {
+	var groups [][]store.Item
+	index := make(map[[3]string]int)
+
+	for _, item := range m.waste {
+		key := [3]string{
+			strings.ToLower(strings.TrimSpace(item.Name)),
+			strings.ToLower(strings.TrimSpace(item.WasteType)),
+			locationKey(item.Location),
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], item)
+	}
+
+	duplicates := groups[:0]
+	for _, group := range groups {
+		if len(group) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+
+	return duplicates
+}
+
+// duplicatesView lists each group of duplicate items under its name, type
+// and location, with the id and quantity of each item in it.
+func (m model) duplicatesView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.title.Render(tr("Possible Duplicates")))
+	b.WriteString("\n")
+
+	groups := m.duplicateGroups()
+	if len(groups) == 0 {
+		b.WriteString(m.theme.help().Render(tr("No two items share a name, type and location")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for _, group := range groups {
+		first := group[0]
+		location := first.Location
+		if strings.TrimSpace(location) == "" {
+			location = tr("(no location)")
+		}
+
+		b.WriteString(m.theme.label.Render(tr("%s · %s · %s (%d items)", first.Name, first.WasteType, location, len(group))))
+		b.WriteString("\n")
+
+		for _, item := range group {
+			item = m.display(item)
+			fmt.Fprintf(&b, "  #%-6d %s  %s\n",
+				item.ID,
+				strings.TrimSpace(m.formatQuantity(item.Quantity)+" "+item.Unit),
+				m.theme.help().Render(item.CreatedAt.Local().Format("2006-01-02 15:04")))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// defaultEditor is run to edit notes when neither $VISUAL nor $EDITOR is
+// set.
+const defaultEditor = "vi"
+
+// notesEditedMsg carries the notes back from the editor. id is the item
+// whose notes were edited from the details, or zero for the form.
+type notesEditedMsg struct {
+	id    int
+	notes string
+	err   error
+}
+
+// editorCommand returns the editor to run on path, from $VISUAL or
+// $EDITOR, which may include arguments such as "code --wait".
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{defaultEditor}
+	}
+
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// editNotes writes notes to a temporary file and hands the terminal to the
+// editor on it. Once the editor exits, the file is read back and removed,
+// and the notes are sent as a notesEditedMsg for id.
+func editNotes(id int, notes string) tea.Cmd {
+	f, err := os.CreateTemp("", "wmtui-notes-*.txt")
+	if err != nil {
+		return func() tea.Msg {
+			return notesEditedMsg{id: id, err: err}
+		}
+	}
+
+	path := f.Name()
+	_, err = f.WriteString(notes)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg {
+			return notesEditedMsg{id: id, err: err}
+		}
+	}
+
+	return tea.Exec(foregroundCmd{editorCommand(path)}, func(err error) tea.Msg {
+		defer os.Remove(path)
+
+		if err != nil {
+			return notesEditedMsg{id: id, err: err}
+		}
+
+		b, err := os.ReadFile(path)
+		return notesEditedMsg{id: id, notes: strings.TrimSpace(string(b)), err: err}
+	})
+}
+
+// updateNotesEdited takes the notes back from the editor: into the form if
+// they were opened from it, or else saved to the item they were opened
+// from in its details.
+func (m model) updateNotesEdited(msg notesEditedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = fmt.Errorf(tr("failed to edit the notes: %v"), msg.err)
+		return m, nil
+	}
+
+	if msg.id == 0 {
+		if !m.inForm() {
+			return m, nil
+		}
+
+		// The notes input is a single line, so the lines of the file
+		// are joined into one.
+		m.inputs[inputNotes].SetValue(strings.Join(strings.Fields(msg.notes), " "))
+		m.inputs[inputNotes].CursorEnd()
+		m.err = nil
+		return m, nil
+	}
+
+	for i, before := range m.waste {
+		if before.ID != msg.id {
+			continue
+		}
+		if before.Notes == msg.notes {
+			return m, nil
+		}
+
+		item := before
+		item.Notes = msg.notes
+
+		item, err := m.store.Update(item)
+		if err != nil {
+			m.err = fmt.Errorf(tr("failed to update item: %v"), err)
+			return m, nil
+		}
+
+		m.record(change{kind: changeEdit, items: []store.Item{before}, edited: item})
+
+		m.waste[i] = item
+		m.filtered = m.filterItems()
+		m.selectID(item.ID)
+		m.setStatus(tr("Saved the notes of '%s'", item.Name))
+		return m, nil
+	}
+
+	m.err = errors.New(tr("the item is no longer in the list, so its notes were not saved"))
+	return m, nil
+}
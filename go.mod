@@ -3,6 +3,7 @@ module github.com/shotoyaar/waste_management_tui
 go 1.22.7
 
 require (
+	github.com/atotto/clipboard v0.1.4 // direct
 	github.com/charmbracelet/bubbletea v1.1.1 // direct
 	github.com/charmbracelet/lipgloss v0.13.0 // direct
 	github.com/mattn/go-sqlite3 v1.14.23 // direct
@@ -11,7 +12,6 @@ require (
 require github.com/charmbracelet/bubbles v0.20.0
 
 require (
-	github.com/atotto/clipboard v0.1.4 // indirect
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
 	github.com/charmbracelet/x/ansi v0.2.3 // indirect
 	github.com/charmbracelet/x/term v0.2.0 // indirect
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// locationKey is what items are grouped by, so that locations differing
+// only in case or surrounding spaces share a group.
+func locationKey(location string) string {
+	return strings.ToLower(strings.TrimSpace(location))
+}
+
+// groupByLocation orders indices by location, keeping the existing order
+// within each location. Items without a location come last.
+func (m model) groupByLocation(indices []int) {
+	sort.SliceStable(indices, func(i, j int) bool {
+		a, b := locationKey(m.waste[indices[i]].Location), locationKey(m.waste[indices[j]].Location)
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		return a < b
+	})
+}
+
+// startsGroup reports whether row i of filtered has a location header
+// above it, when start is the first row shown.
+func (m model) startsGroup(i, start int) bool {
+	if !m.groupLocations {
+		return false
+	}
+	return i == start || locationKey(m.waste[m.filtered[i-1]].Location) != locationKey(m.waste[m.filtered[i]].Location)
+}
+
+// groupHeader returns the location header drawn above row i of filtered
+// when the table is grouped, which is whenever row i starts a group or the
+// page. The header ends with the group's subtotal per unit.
+func (m model) groupHeader(i, start int) (string, bool) {
+	if !m.startsGroup(i, start) {
+		return "", false
+	}
+
+	key := locationKey(m.waste[m.filtered[i]].Location)
+
+	title := strings.TrimSpace(m.waste[m.filtered[i]].Location)
+	if title == "" {
+		title = tr("(no location)")
+	}
+
+	var units []string
+	totals := make(map[string]float64)
+	count := 0
+
+	for _, index := range m.filtered {
+		item := m.display(m.waste[index])
+		if locationKey(item.Location) != key {
+			continue
+		}
+		if _, ok := totals[item.Unit]; !ok {
+			units = append(units, item.Unit)
+		}
+		totals[item.Unit] += item.Quantity
+		count++
+	}
+
+	subtotals := make([]string, len(units))
+	for j, unit := range units {
+		subtotals[j] = strings.TrimSpace(m.formatQuantity(totals[unit]) + " " + unit)
+	}
+
+	return tr("▸ %s (%d) · %s", title, count, strings.Join(subtotals, ", ")), true
+}
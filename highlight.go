@@ -0,0 +1,53 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// addedTimeout is how long newly added or imported rows stay highlighted.
+const addedTimeout = 3 * time.Second
+
+// addedTimeoutMsg ends the highlight of the rows added, unless more were
+// added since it was scheduled.
+type addedTimeoutMsg struct {
+	seq int
+}
+
+// markAdded highlights the items with the given ids until addedTimeout
+// passes, replacing any earlier highlight.
+func (m *model) markAdded(ids []int) tea.Cmd {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	m.added = make(map[int]bool, len(ids))
+	for _, id := range ids {
+		m.added[id] = true
+	}
+	m.addedSeq++
+
+	seq := m.addedSeq
+	return tea.Tick(addedTimeout, func(time.Time) tea.Msg {
+		return addedTimeoutMsg{seq: seq}
+	})
+}
+
+// newIDs returns the ids of the items in waste that are not in before.
+func newIDs(before, waste []store.Item) []int {
+	seen := make(map[int]bool, len(before))
+	for _, item := range before {
+		seen[item.ID] = true
+	}
+
+	var ids []int
+	for _, item := range waste {
+		if !seen[item.ID] {
+			ids = append(ids, item.ID)
+		}
+	}
+
+	return ids
+}
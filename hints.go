@@ -0,0 +1,65 @@
+package main
+
+import "unicode/utf8"
+
+// hintMode returns the keymap mode of the current input mode, or "" for
+// the modes without a hint line, which have their keys spelled out below
+// them already.
+func (m model) hintMode() string {
+	switch m.inputmode {
+	case normal:
+		return "List"
+	case addingName, addingQuantity, addingWasteType, addingLocation, addingMethod, editing:
+		return "Add / Edit"
+	case confirmingMerge:
+		return "Duplicate Found"
+	case searching:
+		return "Search"
+	case filteringQuantity:
+		return "Quantity Filter"
+	case importing:
+		return "Import"
+	case viewingTrash:
+		return "Trash"
+	case choosingColumns:
+		return "Columns"
+	case viewingAudit:
+		return "Audit Log"
+	}
+	return ""
+}
+
+// showHints reports whether the hint line is drawn under the title.
+func (m model) showHints() bool {
+	return !m.noHints && m.store != nil && !m.recoverable() && m.hintMode() != ""
+}
+
+// hintLine renders the hints of the current mode's keybindings on one
+// line, in the order of the keymap. Hints that would run past the edge of
+// the window are left off, rather than cut in half.
+func (m model) hintLine() string {
+	var group keyGroup
+	for _, g := range keymap {
+		if g.mode == m.hintMode() {
+			group = g
+		}
+	}
+
+	line := ""
+	for _, binding := range group.bindings {
+		if binding.hint == "" || m.hideBinding(group, binding) {
+			continue
+		}
+
+		next := binding.keys + " " + tr(binding.hint)
+		if line != "" {
+			next = line + " · " + next
+		}
+		if m.width > 0 && utf8.RuneCountInString(next) > m.width {
+			break
+		}
+		line = next
+	}
+
+	return m.theme.help().Render(line)
+}
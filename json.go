@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+const jsonExportPath = "waste_export.json"
+
+// exportJSON writes every field of items to path as a JSON array, so that
+// importJSON can restore them exactly.
+func exportJSON(path string, items []store.Item) error {
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// importJSON saves the items in the JSON file at path in a single
+// transaction, replacing stored items that have the same id.
+func (m model) importJSON(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var items []store.Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return 0, err
+	}
+
+	if err := m.store.Upsert(items); err != nil {
+		return 0, err
+	}
+
+	return len(items), nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// jumpTimeout is how long after the last digit a typed row number is
+// jumped to without pressing enter.
+const jumpTimeout = time.Second
+
+// jumpTimeoutMsg resolves the row number typed so far, unless more digits
+// were typed since it was scheduled.
+type jumpTimeoutMsg struct {
+	seq int
+}
+
+// addJumpDigit appends a digit to the row number being typed and restarts
+// the timeout.
+func (m model) addJumpDigit(digit string) (tea.Model, tea.Cmd) {
+	if len(m.jump) >= 6 {
+		return m, nil
+	}
+
+	m.jump += digit
+	m.jumpSeq++
+
+	seq := m.jumpSeq
+	return m, tea.Tick(jumpTimeout, func(time.Time) tea.Msg {
+		return jumpTimeoutMsg{seq: seq}
+	})
+}
+
+// resolveJump moves the cursor to the typed row number, counting from one
+// and clamped to the visible rows.
+func (m model) resolveJump() (tea.Model, tea.Cmd) {
+	n, err := strconv.Atoi(m.jump)
+	m.jump = ""
+
+	if err != nil || len(m.filtered) == 0 {
+		return m, nil
+	}
+
+	m.cursor = min(max(n, 1), len(m.filtered)) - 1
+	m.setStatus(tr("Row %d of %d", m.cursor+1, len(m.filtered)))
+
+	return m, nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keyHelp describes one keybinding in the help overlay. The few with a
+// hint are also shown on the hint line under the title, with the hint as
+// a shorter description.
+type keyHelp struct {
+	keys string
+	desc string
+	hint string
+}
+
+// keyGroup is the set of keybindings available in one mode.
+type keyGroup struct {
+	mode     string
+	bindings []keyHelp
+}
+
+// keymap lists every keybinding by mode. The help overlay and the hint line
+// are rendered from it, so add new shortcuts here as well as to the mode's
+// update function. The modes, descriptions and hints are translated when
+// they are drawn.
+var keymap = []keyGroup{
+	{"List", []keyHelp{
+		{"up/k, down/j", "move the cursor", ""},
+		{"g/home, G/end", "jump to the first or last item", ""},
+		{"0-9, enter", "jump to a row by number", ""},
+		{"[, ], pgup/pgdown", "move up or down a page", ""},
+		{"enter", "show item details", "details"},
+		{"click, double-click", "move to a row, or show its details", ""},
+		{"click a heading", "sort by the column, or reverse the sort", ""},
+		{"a", "add an item", "add"},
+		{"e", "edit the selected item", "edit"},
+		{"c", "add a copy of the selected item", ""},
+		{"y, Y", "copy the item to the clipboard as a line of text or as JSON", ""},
+		{"space", "select or unselect the item", ""},
+		{"d", "move the item, or all selected items, to the trash", "delete"},
+		{"u, ctrl+z", "undo the last add, edit or delete", ""},
+		{"ctrl+y", "redo the last change undone", ""},
+		{"ctrl+d", "remove every item for good, after typing DELETE", ""},
+		{"T", "show the trash", ""},
+		{"r", "reload the items from the database", ""},
+		{"A", "show the audit log of changes", ""},
+		{"+/-", "adjust quantity by a step, 1 unless the config file sets one", ""},
+		{"shift+up/down", "adjust quantity by ten steps", ""},
+		{"/", "search", "search"},
+		{"esc", "clear the selection, or else the search", ""},
+		{"s, S", "change the sort column or direction", ""},
+		{"o", "show only overdue items", ""},
+		{"h", "show only hazardous items", ""},
+		{"R", "show only recurring items due for collection again", ""},
+		{"f", "show only one status, cycling through them", ""},
+		{"#", "show only one tag, cycling through them", ""},
+		{">", "show only quantities in a range, such as >100 or 10-50", ""},
+		{"v", "choose which columns are shown", ""},
+		{"L", "group the items by location, with subtotals", ""},
+		{"w, m", "show only items created in the last 7 or 30 days", ""},
+		{"n", "move the item on to its next status", ""},
+		{"t", "show totals by type", ""},
+		{"U", "show masses as entered, in kg or in lb", ""},
+		{"z", "switch between compact rows and comfortable ones with notes, tags and cost", ""},
+		{"C", "chart the quantity of each type", ""},
+		{"D", "list the items that share a name, type and location", ""},
+		{"l", "show a label of the item to print and stick on its container", ""},
+		{"x, X", "export the selected items, or else those shown, to CSV or JSON", ""},
+		{"M", "export them as a Markdown report instead", ""},
+		{"alt+x, alt+X, alt+M", "export every item, ignoring the filters and selection", ""},
+		{"b", "back up the database", ""},
+		{"i", "import a CSV or JSON file", ""},
+		{"p", "switch to the next profile", ""},
+		{"ctrl+r", "change the cursor style", ""},
+		{"ctrl+t", "switch to the next color theme", ""},
+		{"?", "show this help", "help"},
+		{"q, ctrl+c", "quit", "quit"},
+	}},
+	{"Add / Edit", []keyHelp{
+		{"tab, down", "next field", "next"},
+		{"tab", "on the type, location or frequency, complete it", ""},
+		{"ctrl+n, ctrl+p", "on the type, location or frequency, cycle through the completions", ""},
+		{"left/right", "on the location, when only some are allowed, choose one", ""},
+		{"ctrl+o", "edit the notes in $EDITOR", ""},
+		{"shift+tab, up", "previous field", "previous"},
+		{"enter", "next field, or save on the last one", "next or save"},
+		{"esc", "cancel", "cancel"},
+		{"ctrl+c", "quit", ""},
+	}},
+	{"Duplicate Found", []keyHelp{
+		{"m", "add the quantity to the existing item", "merge"},
+		{"a", "add as a new item anyway", "add anyway"},
+		{"esc", "return to the form", "back"},
+	}},
+	{"Search", []keyHelp{
+		{"ctrl+f", "switch between fuzzy and substring matching", "fuzzy or substring"},
+		{"enter", "keep the filter", "keep"},
+		{"esc", "clear the filter", "clear"},
+	}},
+	{"Quantity Filter", []keyHelp{
+		{"enter", "keep the filter", "keep"},
+		{"esc", "clear the filter", "clear"},
+	}},
+	{"Import", []keyHelp{
+		{"enter", "import the file", "import"},
+		{"esc", "cancel", "cancel"},
+	}},
+	{"Trash", []keyHelp{
+		{"up/k, down/j", "move the cursor", ""},
+		{"r", "restore the selected item", "restore"},
+		{"D", "delete the selected item permanently", "delete for good"},
+		{"esc, T", "return to the list", "back"},
+	}},
+	{"Columns", []keyHelp{
+		{"up/k, down/j", "move the cursor", ""},
+		{"space, enter", "show or hide the column", "show or hide"},
+		{"esc, v", "return to the list", "back"},
+	}},
+	{"Audit Log", []keyHelp{
+		{"up/k, down/j", "move the cursor", ""},
+		{"[, ], pgup/pgdown", "previous or next page", "page"},
+		{"esc, A", "return to the list", "back"},
+	}},
+	{"Chart", []keyHelp{
+		{"enter, esc, C", "return to the list", ""},
+	}},
+	{"Duplicates", []keyHelp{
+		{"enter, esc, D", "return to the list", ""},
+	}},
+	{"Label", []keyHelp{
+		{"s", "save the label to a text file", ""},
+		{"enter, esc, l", "return to the list", ""},
+	}},
+	{"Details", []keyHelp{
+		{"ctrl+o", "edit the notes in $EDITOR and save them", ""},
+		{"enter, esc", "return to the list", ""},
+	}},
+	{"Confirmations", []keyHelp{
+		{"y", "confirm", ""},
+		{"any other key", "cancel", ""},
+	}},
+}
+
+// helpView renders the keymap grouped by mode.
+func (m model) helpView() string {
+	width := 0
+	for _, group := range keymap {
+		for _, binding := range group.bindings {
+			width = max(width, len(binding.keys))
+		}
+	}
+
+	var b strings.Builder
+
+	for _, group := range keymap {
+		if m.hideGroup(group) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(m.theme.label.Render(tr(group.mode)))
+		b.WriteString("\n")
+
+		for _, binding := range group.bindings {
+			if m.hideBinding(group, binding) {
+				continue
+			}
+			fmt.Fprintf(&b, "  %s  %s\n", m.theme.focused.Render(fmt.Sprintf("%-*s", width, binding.keys)), tr(binding.desc))
+		}
+	}
+
+	return m.theme.detail.Render(strings.TrimSuffix(b.String(), "\n"))
+}
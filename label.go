@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// labelWidth is how many characters wide a label is, borders included,
+// which fits the narrowest common label printers.
+const labelWidth = 40
+
+// labelExportPath is the file a label is written to, by item id.
+func labelExportPath(id int) string {
+	return fmt.Sprintf("waste_label_%d.txt", id)
+}
+
+func (m model) updateLabel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "esc", "enter", "l":
+		m.inputmode = normal
+
+	case "s":
+		item := m.waste[m.current()]
+		path := labelExportPath(item.ID)
+		if err := os.WriteFile(path, []byte(m.itemLabel(item)), 0o644); err != nil {
+			m.err = fmt.Errorf(tr("failed to save label: %v"), err)
+			return m, nil
+		}
+
+		m.inputmode = normal
+		m.setStatus(tr("Saved the label for '%s' to %s", item.Name, path))
+	}
+
+	return m, nil
+}
+
+// itemLabel draws item as a label to stick on its container: a
+// labelWidth box of plain ASCII, so that it prints on anything, with the
+// item's name, type, quantity, location and disposal method. Values too
+// long for a line are wrapped onto the next. Like the exports, the label
+// is in English whatever the language of the interface.
+func (m model) itemLabel(item store.Item) string {
+	inner := labelWidth - 4
+	rule := "+" + strings.Repeat("-", labelWidth-2) + "+\n"
+
+	var b strings.Builder
+	line := func(s string) {
+		fmt.Fprintf(&b, "| %s |\n", fit(s, inner))
+	}
+
+	b.WriteString(rule)
+	for _, s := range wrapText(strings.ToUpper(item.Name), inner) {
+		line(s)
+	}
+	if item.Hazardous {
+		line("!! HAZARDOUS !!")
+	}
+	b.WriteString(rule)
+
+	fields := []struct{ label, value string }{
+		{"Type", item.WasteType},
+		{"Quantity", strings.TrimSpace(m.formatQuantity(item.Quantity) + " " + item.Unit)},
+		{"Location", item.Location},
+		{"Disposal", item.Method},
+		{"Dispose by", item.DisposalDate},
+	}
+
+	const labelColumn = len("Dispose by: ")
+	for _, f := range fields {
+		prefix := fmt.Sprintf("%-*s", labelColumn, f.label+":")
+		for _, s := range wrapText(f.value, inner-labelColumn) {
+			line(prefix + s)
+			prefix = strings.Repeat(" ", labelColumn)
+		}
+	}
+
+	line("")
+	line(fmt.Sprintf("Item #%d", item.ID))
+	b.WriteString(rule)
+
+	return b.String()
+}
+
+// wrapText breaks s into lines of at most width characters, between words
+// where it can. It always returns at least one line, which is empty for an
+// empty s.
+func wrapText(s string, width int) []string {
+	var lines []string
+	current := ""
+
+	for _, word := range strings.Fields(s) {
+		for len([]rune(word)) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			r := []rune(word)
+			lines = append(lines, string(r[:width]))
+			word = string(r[width:])
+		}
+
+		switch {
+		case current == "":
+			current = word
+		case len([]rune(current))+1+len([]rune(word)) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+
+	return append(lines, current)
+}
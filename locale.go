@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// catalog maps the English text of messages to their translation into one
+// language. Messages a catalog does not have are shown in English.
+type catalog map[string]string
+
+// catalogs are the languages the interface can be shown in, by language
+// code. The messages are written in English, so its catalog is empty.
+var catalogs = map[string]catalog{
+	"en": {},
+	"es": spanish,
+}
+
+// messages is the catalog of the language chosen at startup.
+var messages = catalogs["en"]
+
+// tr translates message into the chosen language and, if args are given,
+// formats them into it as fmt.Sprintf does. A translation must take the
+// same arguments as the English message, in the same order.
+func tr(message string, args ...any) string {
+	if translated, ok := messages[message]; ok {
+		message = translated
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// setLanguage switches to the catalog for lang, which may be a language
+// code such as "es" or a locale such as "es_ES.UTF-8". An empty lang picks
+// the language from $LANG, falling back to English if there is no catalog
+// for it. It is an error to ask for a language by name that there is no
+// catalog for.
+func setLanguage(lang string) error {
+	if lang == "" {
+		if c, ok := catalogFor(os.Getenv("LANG")); ok {
+			messages = c
+		}
+		return nil
+	}
+
+	c, ok := catalogFor(lang)
+	if !ok {
+		return fmt.Errorf("unknown language %q, expected one of %s", lang, strings.Join(languages(), ", "))
+	}
+
+	messages = c
+	return nil
+}
+
+// catalogFor returns the catalog for a language code or locale, trying
+// the full locale before its language, so that es_ES falls back to es.
+func catalogFor(locale string) (catalog, bool) {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.ToLower(strings.ReplaceAll(locale, "-", "_"))
+
+	if c, ok := catalogs[locale]; ok {
+		return c, true
+	}
+
+	language, _, _ := strings.Cut(locale, "_")
+	c, ok := catalogs[language]
+	return c, ok
+}
+
+// languages returns the codes of the available languages, sorted.
+func languages() []string {
+	codes := make([]string, 0, len(catalogs))
+	for code := range catalogs {
+		codes = append(codes, code)
+	}
+
+	sort.Strings(codes)
+	return codes
+}
@@ -0,0 +1,314 @@
+package main
+
+// spanish is the Spanish catalog, keyed by the English text of each
+// message in the order they appear in the source.
+var spanish = catalog{
+	"failed to load audit log: %v":      "no se pudo cargar el registro de cambios: %v",
+	"Audit Log":                         "Registro de cambios",
+	"Nothing has been changed yet":      "Todavía no se ha cambiado nada",
+	"When":                              "Cuándo",
+	"Action":                            "Acción",
+	"Item":                              "Artículo",
+	"User":                              "Usuario",
+	"Name":                              "Nombre",
+	"Page %d of %d":                     "Página %d de %d",
+	"Snapshot":                          "Instantánea",
+	"Quantity by Type":                  "Cantidad por tipo",
+	"There are no items to chart":       "No hay artículos que representar",
+	"(no type)":                         "(sin tipo)",
+	"at least one column must be shown": "hay que mostrar al menos una columna",
+	"Columns":                           "Columnas",
+	"Error: %v":                         "Error: %v",
+	"(no location)":                     "(sin ubicación)",
+	"▸ %s (%d) · %s":                    "▸ %s (%d) · %s",
+	"Row %d of %d":                      "Fila %d de %d",
+	"Waste Name":                        "Nombre del residuo",
+	"Waste Quantity":                    "Cantidad de residuo",
+	"Unit (kg, l, pcs)":                 "Unidad (kg, l, pcs)",
+	"Waste Type":                        "Tipo de residuo",
+	"Waste Location":                    "Ubicación del residuo",
+	"Disposal Method":                   "Método de eliminación",
+	"Disposal Date (YYYY-MM-DD)":        "Fecha de eliminación (AAAA-MM-DD)",
+	"Hazardous? (y/n)":                  "¿Peligroso? (y/n)",
+	"Disposal Cost":                     "Coste de eliminación",
+	"Collection Frequency (blank if one-off)":              "Frecuencia de recogida (vacía si es única)",
+	"Tags (comma-separated)":                               "Etiquetas (separadas por comas)",
+	"Notes":                                                "Notas",
+	"path/to/file.csv or .json":                            "ruta/al/archivo.csv o .json",
+	"Import from: ":                                        "Importar desde: ",
+	"Type %s to confirm: ":                                 "Escriba %s para confirmar: ",
+	"path/to/backup.bak":                                   "ruta/a/copia.bak",
+	"Restore from: ":                                       "Restaurar desde: ",
+	"error loading waste items: %v":                        "error al cargar los residuos: %v",
+	"error loading settings: %v":                           "error al cargar los ajustes: %v",
+	"Colors are disabled":                                  "Los colores están desactivados",
+	"Theme: %s":                                            "Tema: %s",
+	"failed to export items: %v":                           "no se pudieron exportar los artículos: %v",
+	"Exported %d items to %s":                              "Se exportaron %d artículos a %s",
+	"failed to export report: %v":                          "no se pudo exportar el informe: %v",
+	"Wrote a report of %d items to %s":                     "Se escribió un informe de %d artículos en %s",
+	"failed to copy item: %v":                              "no se pudo copiar el artículo: %v",
+	"Copied '%s' to the clipboard":                         "Se copió '%s' al portapapeles",
+	"failed to update item: %v":                            "no se pudo actualizar el artículo: %v",
+	"'%s' is now %s":                                       "'%s' ahora está %s",
+	"only databases stored in a file can be backed up":     "solo se pueden copiar bases de datos guardadas en un archivo",
+	"failed to back up database: %v":                       "no se pudo hacer la copia de seguridad: %v",
+	"Backed up to %s":                                      "Copia de seguridad en %s",
+	"failed to reload items: %v":                           "no se pudieron recargar los artículos: %v",
+	"Refreshed":                                            "Actualizado",
+	"failed to restore item: %v":                           "no se pudo restaurar el artículo: %v",
+	"Restored '%s'":                                        "Se restauró '%s'",
+	"Nothing was removed":                                  "No se eliminó nada",
+	"failed to remove items: %v":                           "no se pudieron eliminar los artículos: %v",
+	"Removed all %d items":                                 "Se eliminaron los %d artículos",
+	"failed to import %s: %v":                              "no se pudo importar %s: %v",
+	"Imported %d items, skipped %d rows":                   "Se importaron %d artículos y se omitieron %d filas",
+	"failed to delete item: %v":                            "no se pudo borrar el artículo: %v",
+	"Moved '%s' to the trash":                              "Se movió '%s' a la papelera",
+	"failed to delete items: %v":                           "no se pudieron borrar los artículos: %v",
+	"Moved %d items to the trash":                          "Se movieron %d artículos a la papelera",
+	"quantity is required":                                 "la cantidad es obligatoria",
+	"quantity must be a number":                            "la cantidad debe ser un número",
+	"quantity must be greater than zero":                   "la cantidad debe ser mayor que cero",
+	"must be a number":                                     "debe ser un número",
+	"cost must be a number":                                "el coste debe ser un número",
+	"cost cannot be negative":                              "el coste no puede ser negativo",
+	"invalid answer %q":                                    "respuesta no válida %q",
+	"one-off":                                              "única",
+	"%s, next due %s":                                      "%s, próxima el %s",
+	"yes":                                                  "sí",
+	"no":                                                   "no",
+	"name is required":                                     "el nombre es obligatorio",
+	"waste type is required":                               "el tipo de residuo es obligatorio",
+	"disposal date must be YYYY-MM-DD":                     "la fecha de eliminación debe ser AAAA-MM-DD",
+	"hazardous must be y or n":                             "peligroso debe ser y o n",
+	"frequency must be one of %s, or blank":                "la frecuencia debe ser una de %s, o vacía",
+	"Updated '%s'":                                         "Se actualizó '%s'",
+	"failed to add item: %v":                               "no se pudo añadir el artículo: %v",
+	"Added '%s'":                                           "Se añadió '%s'",
+	"failed to merge item: %v":                             "no se pudo fusionar el artículo: %v",
+	"Merged into '%s'":                                     "Fusionado en '%s'",
+	"%s:%d: expected name=path":                            "%s:%d: se esperaba nombre=ruta",
+	"No other profiles configured":                         "No hay otros perfiles configurados",
+	"failed to open profile %s: %v":                        "no se pudo abrir el perfil %s: %v",
+	"failed to load profile %s: %v":                        "no se pudo cargar el perfil %s: %v",
+	"failed to save settings: %v":                          "no se pudieron guardar los ajustes: %v",
+	"failed to load settings: %v":                          "no se pudieron cargar los ajustes: %v",
+	"Switched to profile %s":                               "Perfil cambiado a %s",
+	"failed to copy %s: %v":                                "no se pudo copiar %s: %v",
+	"cannot use %s: %v":                                    "no se puede usar %s: %v",
+	"failed to move %s aside: %v":                          "no se pudo apartar %s: %v",
+	"failed to restore %s: %v":                             "no se pudo restaurar %s: %v",
+	"Restored %s; the damaged file is now %s":              "Se restauró %s; el archivo dañado ahora es %s",
+	"Started a new database; the damaged file is now %s":   "Se creó una base de datos nueva; el archivo dañado ahora es %s",
+	"The database %s cannot be read: %v":                   "No se puede leer la base de datos %s: %v",
+	"Press (enter) to restore the backup, (esc) to cancel": "Pulse (enter) para restaurar la copia, (esc) para cancelar",
+	"Press (n) for a new database, (b) to restore a backup, (q) to quit": "Pulse (n) para una base de datos nueva, (b) para restaurar una copia, (q) para salir",
+	"Search (substring, ctrl+f for fuzzy)":                               "Buscar (subcadena, ctrl+f para aproximada)",
+	"Search (fuzzy, ctrl+f for substring)":                               "Buscar (aproximada, ctrl+f para subcadena)",
+	"[Submit]":                                                           "[Enviar]",
+	"Submit":                                                             "Enviar",
+	"failed to load trash: %v":                                           "no se pudo cargar la papelera: %v",
+	"failed to purge item: %v":                                           "no se pudo eliminar el artículo: %v",
+	"Permanently deleted '%s'":                                           "Se eliminó '%s' para siempre",
+	"Trash":                                                              "Papelera",
+	"The trash is empty":                                                 "La papelera está vacía",
+	"Deleted":                                                            "Borrado",
+	"%d items":                                                           "%d artículos",
+	"showing %d of %d":                                                   "mostrando %d de %d",
+	", %d selected":                                                      ", %d seleccionados",
+	" · %s only":                                                         " · solo %s",
+	" · tagged %s":                                                       " · con etiqueta %s",
+	" · by location":                                                     " · por ubicación",
+	" · created in the last %d days":                                     " · creados en los últimos %d días",
+	" · go to row %s":                                                    " · ir a la fila %s",
+	"Totals by Type":                                                     "Totales por tipo",
+	"Total":                                                              "Total",
+	"Cost by Type":                                                       "Coste por tipo",
+	"Items":                                                              "Artículos",
+	"Average":                                                            "Media",
+	"Smallest":                                                           "Mínimo",
+	"Largest":                                                            "Máximo",
+	"Waste Management System":                                            "Sistema de gestión de residuos",
+	"Press (q) to quit":                                                  "Pulse (q) para salir",
+	"Press (enter) or (esc) to return to the list":                                                    "Pulse (enter) o (esc) para volver a la lista",
+	"Press any key to close":                                                                          "Pulse cualquier tecla para cerrar",
+	"Permanently delete '%s'? This cannot be undone. (y/n)":                                           "¿Eliminar '%s' para siempre? No se puede deshacer. (y/n)",
+	"Press (r) to restore, (D) to delete permanently, up/down or j/k to move, (esc) or (T) to return": "Pulse (r) para restaurar, (D) para eliminar para siempre, arriba/abajo o j/k para moverse, (esc) o (T) para volver",
+	"Press (enter), (esc) or (C) to return to the list":                                               "Pulse (enter), (esc) o (C) para volver a la lista",
+	"Press (space) to show or hide the column, up/down or j/k to move, (esc) or (v) to return":        "Pulse (espacio) para mostrar u ocultar la columna, arriba/abajo o j/k para moverse, (esc) o (v) para volver",
+	"Press up/down or j/k to move, [/] to page, (esc) or (A) to return":                               "Pulse arriba/abajo o j/k para moverse, [/] para cambiar de página, (esc) o (A) para volver",
+	"Current Waste Items": "Residuos actuales",
+	" · masses in %s":     " · masas en %s",
+	"Rows %d–%d of %d":    "Filas %d–%d de %d",
+	"No waste items yet":  "Todavía no hay residuos",
+	" — press (a) to add your first one, or (i) to import some":                 " — pulse (a) para añadir el primero, o (i) para importar algunos",
+	"Move %d selected items to the trash? (y/n)":                                "¿Mover los %d artículos seleccionados a la papelera? (y/n)",
+	"Move '%s' (%s) to the trash? (y/n)":                                        "¿Mover '%s' (%s) a la papelera? (y/n)",
+	"Similar item exists: '%s' (%s %s) — merge quantities or add anyway?":       "Ya existe un artículo parecido: '%s' (%s %s) — ¿fusionar las cantidades o añadirlo igualmente?",
+	"Discard unsaved changes? (y/n)":                                            "¿Descartar los cambios sin guardar? (y/n)",
+	"This permanently removes every item, %d in the list and any in the trash.": "Esto elimina para siempre todos los artículos, %d en la lista y los que haya en la papelera.",
+	"Edit Waste Item":                               "Editar residuo",
+	"Add New Waste Item":                            "Añadir residuo",
+	"cursor mode is ":                               "el modo del cursor es ",
+	" (ctrl+r to change style)":                     " (ctrl+r para cambiar el estilo)",
+	"Press (y) to confirm, any other key to cancel": "Pulse (y) para confirmar, cualquier otra tecla para cancelar",
+	"Press (m) to merge, (a) to add anyway, (esc) to return to the form":                                                                                     "Pulse (m) para fusionar, (a) para añadirlo igualmente, (esc) para volver al formulario",
+	"Type to filter, (ctrl+f) to switch fuzzy/substring matching, (enter) to keep the filter, (esc) to clear":                                                "Escriba para filtrar, (ctrl+f) para alternar entre búsqueda aproximada y por subcadena, (enter) para mantener el filtro, (esc) para borrarlo",
+	"Press (enter) to import the file, (esc) to cancel":                                                                                                      "Pulse (enter) para importar el archivo, (esc) para cancelar",
+	"Press (enter) to confirm, (esc) to cancel":                                                                                                              "Pulse (enter) para confirmar, (esc) para cancelar",
+	"Press (a) to add, (e) to edit, (enter) for details, (d) to delete, (/) to search, (s/S) to sort, up/down or j/k to move, (?) for all keys, (q) to quit": "Pulse (a) para añadir, (e) para editar, (enter) para ver detalles, (d) para borrar, (/) para buscar, (s/S) para ordenar, arriba/abajo o j/k para moverse, (?) para ver todas las teclas, (q) para salir",
+	"Press (enter) to move to next field, tab/shift+tab to switch fields, (esc) to cancel":                                                                   "Pulse (enter) para pasar al siguiente campo, tab/shift+tab para cambiar de campo, (esc) para cancelar",
+	"move the cursor":                                                   "mover el cursor",
+	"jump to the first or last item":                                    "ir al primer o al último artículo",
+	"jump to a row by number":                                           "ir a una fila por su número",
+	"move up or down a page":                                            "subir o bajar una página",
+	"show item details":                                                 "ver los detalles del artículo",
+	"move to a row, or show its details":                                "ir a una fila, o ver sus detalles",
+	"sort by the column, or reverse the sort":                           "ordenar por la columna, o invertir el orden",
+	"add an item":                                                       "añadir un artículo",
+	"edit the selected item":                                            "editar el artículo seleccionado",
+	"add a copy of the selected item":                                   "añadir una copia del artículo seleccionado",
+	"copy the item to the clipboard as a line of text or as JSON":       "copiar el artículo al portapapeles como una línea de texto o como JSON",
+	"select or unselect the item":                                       "seleccionar o deseleccionar el artículo",
+	"move the item, or all selected items, to the trash":                "mover el artículo, o todos los seleccionados, a la papelera",
+	"remove every item for good, after typing DELETE":                   "eliminar todos los artículos para siempre, tras escribir DELETE",
+	"show the trash":                                                    "ver la papelera",
+	"reload the items from the database":                                "recargar los artículos de la base de datos",
+	"show the audit log of changes":                                     "ver el registro de cambios",
+	"search":                                                            "buscar",
+	"clear the selection, or else the search":                           "borrar la selección, o si no la búsqueda",
+	"change the sort column or direction":                               "cambiar la columna o el sentido del orden",
+	"show only overdue items":                                           "mostrar solo los artículos vencidos",
+	"show only hazardous items":                                         "mostrar solo los artículos peligrosos",
+	"show only recurring items due for collection again":                "mostrar solo los artículos periódicos pendientes de recogida",
+	"show only one status, cycling through them":                        "mostrar solo un estado, pasando por todos",
+	"show only one tag, cycling through them":                           "mostrar solo una etiqueta, pasando por todas",
+	"choose which columns are shown":                                    "elegir qué columnas se muestran",
+	"group the items by location, with subtotals":                       "agrupar los artículos por ubicación, con subtotales",
+	"show only items created in the last 7 or 30 days":                  "mostrar solo los artículos creados en los últimos 7 o 30 días",
+	"move the item on to its next status":                               "pasar el artículo a su siguiente estado",
+	"show totals by type":                                               "ver los totales por tipo",
+	"show masses as entered, in kg or in lb":                            "mostrar las masas tal como se introdujeron, en kg o en lb",
+	"chart the quantity of each type":                                   "representar la cantidad de cada tipo",
+	"export the selected items, or else those shown, to CSV or JSON":    "exportar los artículos seleccionados, o si no los mostrados, a CSV o JSON",
+	"export them as a Markdown report instead":                          "exportarlos como un informe en Markdown",
+	"export every item, ignoring the filters and selection":             "exportar todos los artículos, sin tener en cuenta los filtros ni la selección",
+	"back up the database":                                              "hacer una copia de seguridad de la base de datos",
+	"import a CSV or JSON file":                                         "importar un archivo CSV o JSON",
+	"switch to the next profile":                                        "cambiar al siguiente perfil",
+	"change the cursor style":                                           "cambiar el estilo del cursor",
+	"switch to the next color theme":                                    "cambiar al siguiente tema de colores",
+	"show this help":                                                    "ver esta ayuda",
+	"quit":                                                              "salir",
+	"next field":                                                        "siguiente campo",
+	"on the type, location or frequency, complete it":                   "en el tipo, la ubicación o la frecuencia, completarlo",
+	"on the type, location or frequency, cycle through the completions": "en el tipo, la ubicación o la frecuencia, recorrer las sugerencias",
+	"previous field":                                                    "campo anterior",
+	"next field, or save on the last one":                               "siguiente campo, o guardar en el último",
+	"cancel":                                                            "cancelar",
+	"add the quantity to the existing item":                             "sumar la cantidad al artículo existente",
+	"add as a new item anyway":                                          "añadirlo igualmente como artículo nuevo",
+	"return to the form":                                                "volver al formulario",
+	"switch between fuzzy and substring matching":                       "alternar entre búsqueda aproximada y por subcadena",
+	"keep the filter":                                                   "mantener el filtro",
+	"clear the filter":                                                  "borrar el filtro",
+	"import the file":                                                   "importar el archivo",
+	"restore the selected item":                                         "restaurar el artículo seleccionado",
+	"delete the selected item permanently":                              "eliminar el artículo seleccionado para siempre",
+	"return to the list":                                                "volver a la lista",
+	"show or hide the column":                                           "mostrar u ocultar la columna",
+	"previous or next page":                                             "página anterior o siguiente",
+	"confirm":                                                           "confirmar",
+	"List":                                                              "Lista",
+	"Add / Edit":                                                        "Añadir / Editar",
+	"Duplicate Found":                                                   "Duplicado encontrado",
+	"Search":                                                            "Búsqueda",
+	"Import":                                                            "Importar",
+	"Chart":                                                             "Gráfico",
+	"Details":                                                           "Detalles",
+	"Confirmations":                                                     "Confirmaciones",
+	"Quantity":                                                          "Cantidad",
+	"Type":                                                              "Tipo",
+	"Location":                                                          "Ubicación",
+	"Dispose By":                                                        "Eliminar antes de",
+	"Hazardous":                                                         "Peligroso",
+	"Created":                                                           "Creado",
+	"Updated":                                                           "Actualizado",
+	"Status":                                                            "Estado",
+	"Cost":                                                              "Coste",
+	"Frequency":                                                         "Frecuencia",
+	"Tags":                                                              "Etiquetas",
+	"Unit":                                                              "Unidad",
+	"It may have been cut short, for example when the disk filled up. You can start over\nwith an empty database, or with a copy of an earlier backup.\nEither way the damaged file is kept, renamed, next to the new one.": "Puede que se cortara, por ejemplo al llenarse el disco. Puede empezar de nuevo\ncon una base de datos vacía o con una copia de seguridad anterior.\nEn ambos casos el archivo dañado se conserva, renombrado, junto al nuevo.",
+	">100, <=5 or 10-50": ">100, <=5 o 10-50",
+	"Quantity: ":         "Cantidad: ",
+	" · quantity %s":     " · cantidad %s",
+	"Type a comparison such as >100 or a range such as 10-50, (enter) to keep the filter, (esc) to clear": "Escriba una comparación como >100 o un rango como 10-50, (enter) para mantener el filtro, (esc) para borrarlo",
+	"show only quantities in a range, such as >100 or 10-50":                                              "mostrar solo las cantidades de un rango, como >100 o 10-50",
+	"Quantity Filter": "Filtro de cantidad",
+	"a range must go from the smaller quantity to the larger, as in 10-50":     "un rango debe ir de la cantidad menor a la mayor, como en 10-50",
+	"expected a quantity, a comparison such as >100, or a range such as 10-50": "se esperaba una cantidad, una comparación como >100 o un rango como 10-50",
+	"read-only": "solo lectura",
+	"Press up/down or j/k to move, (esc) or (T) to return":                                                           "Pulse arriba/abajo o j/k para moverse, (esc) o (T) para volver",
+	"Press (enter) for details, (/) to search, (s/S) to sort, up/down or j/k to move, (?) for all keys, (q) to quit": "Pulse (enter) para ver detalles, (/) para buscar, (s/S) para ordenar, arriba/abajo o j/k para moverse, (?) para ver todas las teclas, (q) para salir",
+	"The database is open read-only":                                                                                 "La base de datos está abierta en modo de solo lectura",
+	"editing '%s'":                                                                                                   "la edición de '%s'",
+	"adding '%s'":                                                                                                    "la adición de '%s'",
+	"adding %d items":                                                                                                "la adición de %d artículos",
+	"deleting '%s'":                                                                                                  "el borrado de '%s'",
+	"deleting %d items":                                                                                              "el borrado de %d artículos",
+	"Nothing to undo":                                                                                                "No hay nada que deshacer",
+	"Nothing to redo":                                                                                                "No hay nada que rehacer",
+	"failed to undo %s: %v":                                                                                          "no se pudo deshacer %s: %v",
+	"Undid %s":                                                                                                       "Se deshizo %s",
+	"failed to redo %s: %v":                                                                                          "no se pudo rehacer %s: %v",
+	"Redid %s":                                                                                                       "Se rehízo %s",
+	"'%s' is no longer in the list":                                                                                  "'%s' ya no está en la lista",
+	"undo the last add, edit or delete":                                                                              "deshacer la última adición, edición o borrado",
+	"redo the last change undone":                                                                                    "rehacer el último cambio deshecho",
+	"switch between compact rows and comfortable ones with notes, tags and cost": "alternar entre filas compactas y otras más espaciadas con notas, etiquetas y coste",
+	"Waste Location (left/right to choose)":                                      "Ubicación del residuo (izquierda/derecha para elegir)",
+	"location must be one of %s":                                                 "la ubicación debe ser una de %s",
+	"on the location, when only some are allowed, choose one":                    "en la ubicación, cuando solo se permiten algunas, elegir una",
+	"Possible Duplicates":                                                        "Posibles duplicados",
+	"No two items share a name, type and location":                               "No hay dos artículos con el mismo nombre, tipo y ubicación",
+	"%s · %s · %s (%d items)":                                                    "%s · %s · %s (%d artículos)",
+	"Press (enter), (esc) or (D) to return to the list":                          "Pulse (enter), (esc) o (D) para volver a la lista",
+	"list the items that share a name, type and location":                        "listar los artículos con el mismo nombre, tipo y ubicación",
+	"Duplicates":                     "Duplicados",
+	"failed to save label: %v":       "no se pudo guardar la etiqueta: %v",
+	"Saved the label for '%s' to %s": "Se guardó la etiqueta de '%s' en %s",
+	"Press (s) to save the label to a text file, (enter), (esc) or (l) to return to the list": "Pulse (s) para guardar la etiqueta en un archivo de texto, (enter), (esc) o (l) para volver a la lista",
+	"show a label of the item to print and stick on its container":                            "ver una etiqueta del artículo para imprimirla y pegarla en su contenedor",
+	"Label":                         "Etiqueta",
+	"save the label to a text file": "guardar la etiqueta en un archivo de texto",
+	"add":                           "añadir",
+	"edit":                          "editar",
+	"delete":                        "eliminar",
+	"details":                       "detalles",
+	"help":                          "ayuda",
+	"next":                          "siguiente",
+	"previous":                      "anterior",
+	"next or save":                  "siguiente o guardar",
+	"merge":                         "combinar",
+	"add anyway":                    "añadir igualmente",
+	"back":                          "volver",
+	"keep":                          "mantener",
+	"clear":                         "borrar",
+	"fuzzy or substring":            "aproximada o literal",
+	"import":                        "importar",
+	"restore":                       "restaurar",
+	"delete for good":               "eliminar para siempre",
+	"show or hide":                  "mostrar u ocultar",
+	"page":                          "página",
+	"failed to edit the notes: %v":  "no se pudieron editar las notas: %v",
+	"Saved the notes of '%s'":       "Se guardaron las notas de '%s'",
+	"the item is no longer in the list, so its notes were not saved":                          "el artículo ya no está en la lista, así que sus notas no se guardaron",
+	"Press (ctrl+o) to edit the notes in your editor, (enter) or (esc) to return to the list": "Pulse (ctrl+o) para editar las notas en su editor, (enter) o (esc) para volver a la lista",
+	"edit the notes in $EDITOR":                                    "editar las notas en $EDITOR",
+	"edit the notes in $EDITOR and save them":                      "editar las notas en $EDITOR y guardarlas",
+	"adjust quantity by a step, 1 unless the config file sets one": "ajustar la cantidad en un paso, 1 salvo que el archivo de configuración fije otro",
+	"adjust quantity by ten steps":                                 "ajustar la cantidad en diez pasos",
+	"'%s' is no longer in the trash":                               "'%s' ya no está en la papelera",
+}
@@ -0,0 +1,42 @@
+package main
+
+import "strings"
+
+// restrictLocations reports whether locations must be chosen from the
+// allowed ones set in the config file, rather than typed freely.
+func (m model) restrictLocations() bool {
+	return len(m.locations) > 0
+}
+
+// allowedLocation returns the allowed location that location names,
+// ignoring case and surrounding spaces, spelled as in the config file.
+func (m model) allowedLocation(location string) (string, bool) {
+	for _, allowed := range m.locations {
+		if strings.EqualFold(allowed, strings.TrimSpace(location)) {
+			return allowed, true
+		}
+	}
+	return "", false
+}
+
+// cycleLocation moves the location input on to the next allowed location,
+// or back to the previous one if delta is negative, wrapping around at
+// either end. A location that is not allowed moves to the first or last.
+func (m *model) cycleLocation(delta int) {
+	input := &m.inputs[inputLocation]
+
+	next := 0
+	if delta < 0 {
+		next = len(m.locations) - 1
+	}
+
+	for i, allowed := range m.locations {
+		if strings.EqualFold(allowed, strings.TrimSpace(input.Value())) {
+			next = (i + delta + len(m.locations)) % len(m.locations)
+			break
+		}
+	}
+
+	input.SetValue(m.locations[next])
+	input.CursorEnd()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+const markdownExportPath = "waste_report.md"
+
+// exportMarkdown writes items to path as a GitHub-flavored Markdown table,
+// followed by their totals per type.
+func (m model) exportMarkdown(path string, items []store.Item) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Waste Report\n\n")
+	fmt.Fprintf(&b, "Generated %s · %d items\n\n", time.Now().Format("2006-01-02 15:04"), len(items))
+
+	titles := append(append([]string(nil), columnTitles...), "Hazardous", "Cost")
+	writeMarkdownRow(&b, titles)
+	writeMarkdownRule(&b, len(titles))
+
+	for _, item := range items {
+		writeMarkdownRow(&b, []string{item.Name, item.WasteType, m.formatQuantity(item.Quantity), item.Unit,
+			item.Location, item.Method, item.CreatedAt.Format(store.DateLayout), item.DisposalDate, item.Status,
+			englishYesNo(item.Hazardous), m.formatCost(item.Cost)})
+	}
+
+	fmt.Fprintf(&b, "\n## Totals by Type\n\n")
+	writeMarkdownRow(&b, []string{"Type", "Quantity", "Unit", "Cost"})
+	writeMarkdownRule(&b, 4)
+
+	for _, t := range typeTotals(items) {
+		writeMarkdownRow(&b, []string{t.wasteType, m.formatQuantity(t.quantity), t.unit, m.formatCost(t.cost)})
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// writeMarkdownRow writes cells as a table row, escaping anything that
+// would break the table.
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		b.WriteString(" " + cell + " |")
+	}
+	b.WriteString("\n")
+}
+
+// writeMarkdownRule writes the line separating a table's header from its
+// rows.
+func writeMarkdownRule(b *strings.Builder, columns int) {
+	b.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+}
@@ -0,0 +1,2206 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+type model struct {
+	store      store.Store
+	waste      []store.Item
+	cursor     int
+	inputs     []textinput.Model
+	inputmode  inputmode
+	err        error
+	status     string
+	statusTTL  int
+	cursorMode cursor.Mode
+	focusIndex int
+	quitFrom   inputmode
+	search     textinput.Model
+	importPath textinput.Model
+	filtered   []int
+	sortColumn sortColumn
+	sortDesc   bool
+	theme      theme
+	width      int
+	height     int
+	pageSize   int
+	showStats  bool
+
+	// offset is the first row of filtered shown in the table.
+	offset int
+
+	// currency is the symbol costs are shown with.
+	currency string
+
+	// precision is the number of decimal places quantities are shown
+	// with. They are stored and exported in full.
+	precision int
+
+	// noColor keeps the plain theme on, so that ctrl+t cannot switch to a
+	// colored one.
+	noColor bool
+
+	// pollInterval is how often the items are reloaded from the store, to
+	// pick up changes made elsewhere, unless it is zero.
+	pollInterval time.Duration
+
+	// readOnly disables the keys that change items, and the store refuses
+	// any changes that get through.
+	readOnly bool
+
+	// defaultSort and defaultSortDesc are the sort order used when none
+	// was saved by the last session.
+	defaultSort     sortColumn
+	defaultSortDesc bool
+
+	overdueOnly bool
+
+	// dueOnly keeps only the recurring items due for collection again.
+	dueOnly       bool
+	hazardousOnly bool
+
+	// massUnit is the unit quantities in a mass unit are shown in, or
+	// empty to show them as entered.
+	massUnit string
+
+	// groupLocations shows the table under a header per location, with
+	// the items of each location together.
+	groupLocations bool
+
+	// wrapNavigation moves the cursor from the last row down to the first,
+	// and from the first up to the last, rather than stopping there.
+	wrapNavigation bool
+
+	// noHints leaves out the line of the current mode's most useful keys
+	// under the title.
+	noHints bool
+
+	// comfortable spaces the table out, with a second line under each row
+	// for the item's notes, tags and cost.
+	comfortable bool
+
+	// statusFilter limits the list to items with that status, unless it
+	// is empty.
+	statusFilter string
+
+	// tagFilter limits the list to items with that tag, unless it is
+	// empty.
+	tagFilter string
+
+	// createdWithin limits the list to items created in that many days
+	// before now, unless it is zero.
+	createdWithin int
+
+	// quantityRange limits the list to items with a quantity in it, and
+	// threshold is where it is typed.
+	quantityRange quantityRange
+	threshold     textinput.Model
+
+	// substringSearch switches the search from fuzzy matching to plain
+	// substring matching.
+	substringSearch bool
+
+	// undo holds the changes that can be undone, most recent last, up to
+	// undoDepth of them, and redo those undone that can be made again.
+	undo      []change
+	redo      []change
+	undoDepth int
+
+	// step is how much +/- change the quantity by, unless unitSteps has
+	// a step for the item's unit, in lower case. Steps are in the unit
+	// the item is stored in, even when masses are shown in another.
+	step      float64
+	unitSteps map[string]float64
+
+	// profiles are the databases that p cycles through, and profile is
+	// the index of the open one.
+	profiles []profile
+	profile  int
+
+	// selected holds the ids of the items marked with space, which d
+	// deletes together.
+	selected map[int]bool
+
+	// checkDuplicates makes adding an item that matches an existing one
+	// ask whether to merge them. pending is the item waiting on that
+	// answer and duplicate the index in waste of the one it matches.
+	checkDuplicates bool
+	pending         *store.Item
+	duplicate       int
+
+	// jump holds the digits of a row number being typed, and jumpSeq
+	// tells its timeout apart from those of earlier numbers.
+	jump    string
+	jumpSeq int
+
+	// added holds the ids of the items just added or imported, which are
+	// highlighted until the timeout numbered addedSeq.
+	added    map[int]bool
+	addedSeq int
+
+	// clickRow and clickTime are the row and time of the last mouse
+	// click, to tell a double click from two single ones.
+	clickRow  int
+	clickTime time.Time
+
+	// hiddenColumns holds the indices into columnTitles of the columns
+	// left out of the table, and columnCursor is the column selected in
+	// the menu that toggles them.
+	hiddenColumns map[int]bool
+	columnCursor  int
+
+	// clearConfirm is where clearWord must be typed to remove every item.
+	clearConfirm textinput.Model
+
+	// restorePath is where a backup is entered to replace a database
+	// that cannot be read, and restoreErr why the last attempt to
+	// replace it failed.
+	restorePath textinput.Model
+	restoreErr  error
+
+	// locations are the only locations the form accepts, chosen with
+	// left and right, unless there are none.
+	locations []string
+
+	// defaults are the values the form inputs start with when adding an
+	// item, by input index.
+	defaults []string
+
+	// opened are the values the form inputs had when the form was last
+	// opened or reset: the defaults, or the fields of the item edited.
+	opened []string
+
+	// fieldErrs holds the errors of the form inputs that failed to
+	// validate on submit, by input index, until they are fixed.
+	fieldErrs map[int]string
+
+	// trash holds the deleted items while the trash is shown.
+	trash       []store.Item
+	trashCursor int
+
+	// audit holds the recent audit entries while the audit log is shown.
+	audit       []store.AuditEntry
+	auditCursor int
+}
+
+type inputmode int
+
+const (
+	normal inputmode = iota
+	addingName
+	addingQuantity
+	addingWasteType
+	addingLocation
+	addingMethod
+	editing
+	confirmingDelete
+	searching
+	importing
+	viewingDetail
+	confirmingQuit
+	viewingHelp
+	viewingTrash
+	confirmingPurge
+	confirmingMerge
+	viewingAudit
+	choosingColumns
+	viewingChart
+	confirmingClear
+	filteringQuantity
+	viewingDuplicates
+	viewingLabel
+)
+
+// Indices of the add/edit form inputs.
+const (
+	inputName = iota
+	inputQuantity
+	inputUnit
+	inputType
+	inputLocation
+	inputMethod
+	inputDisposalDate
+	inputHazardous
+	inputCost
+	inputFrequency
+	inputTags
+	inputNotes
+	inputCount
+)
+
+// inputNames are the names the form inputs go by in the config file, by
+// input index.
+var inputNames = []string{"name", "quantity", "unit", "type", "location", "method", "disposal_date", "hazardous", "cost", "frequency", "tags", "notes"}
+
+// defaultCharLimits are the most characters each form input takes, by
+// input index, unless the config file sets another.
+var defaultCharLimits = []int{64, 16, 16, 64, 128, 256, len(store.DateLayout), 3, 16, 16, 256, 1000}
+
+type sortColumn int
+
+const (
+	sortNone sortColumn = iota
+	sortByName
+	sortByType
+	sortByQuantity
+	sortByUnit
+	sortByLocation
+	sortByMethod
+	sortByCreated
+	sortByDisposalDate
+	sortByStatus
+)
+
+// sortNames are the names sort columns are saved under, by sortColumn.
+var sortNames = []string{"none", "name", "type", "quantity", "unit", "location", "method", "created", "disposal_date", "status"}
+
+var columnTitles = []string{"Name", "Type", "Quantity", "Unit", "Location", "Disposal Method", "Created", "Dispose By", "Status"}
+
+// columnWeights are the column widths used before the terminal size is
+// known, and the proportions used to share out the width once it is.
+var columnWeights = []int{10, 10, 8, 5, 10, 15, 10, 10, 9}
+
+// initialModel builds the model from cfg and loads its items from s. If s
+// is nil or the items cannot be loaded, the error is returned alongside a
+// model without a store, which only displays the error.
+func initialModel(s store.Store, cfg config) (model, error) {
+	m := model{
+		inputs:          make([]textinput.Model, inputCount),
+		defaults:        make([]string, inputCount),
+		store:           s,
+		inputmode:       normal,
+		pageSize:        cfg.pageSize,
+		currency:        cfg.currency,
+		defaultSortDesc: cfg.sortDesc,
+		readOnly:        cfg.readOnly,
+		pollInterval:    cfg.refresh,
+		undoDepth:       defaultUndoDepth,
+		locations:       cfg.locations,
+		wrapNavigation:  cfg.wrapNavigation,
+		unitSteps:       cfg.unitSteps,
+		noHints:         cfg.noHints,
+	}
+
+	for i, name := range sortNames {
+		if name == cfg.sort {
+			m.defaultSort = sortColumn(i)
+		}
+	}
+
+	if m.currency == "" {
+		m.currency = "$"
+	}
+
+	m.precision = defaultPrecision
+	if cfg.precision != nil {
+		m.precision = *cfg.precision
+	}
+
+	if cfg.undoDepth != nil {
+		m.undoDepth = *cfg.undoDepth
+	}
+
+	m.step = 1
+	if cfg.step > 0 {
+		m.step = cfg.step
+	}
+
+	m.theme, _ = themeByName(cfg.theme)
+	if cfg.noColor {
+		m.theme = plainTheme
+		m.noColor = true
+	}
+
+	var t textinput.Model
+
+	for i := range m.inputs {
+		t = textinput.New()
+		t.Cursor.Style = m.theme.focused
+		t.PlaceholderStyle = m.theme.blurred
+		t.CharLimit = defaultCharLimits[i]
+		if limit, ok := cfg.charLimits[i]; ok {
+			t.CharLimit = limit
+		}
+		m.defaults[i] = cfg.defaults[i]
+		t.SetValue(m.defaults[i])
+
+		switch i {
+		case inputName:
+			t.Placeholder = tr("Waste Name")
+			t.Focus()
+			t.PromptStyle = m.theme.focused
+			t.TextStyle = m.theme.focused
+
+		case inputQuantity:
+			t.Placeholder = tr("Waste Quantity")
+			t.Validate = validateNumber
+
+		case inputUnit:
+			t.Placeholder = tr("Unit (kg, l, pcs)")
+
+		case inputType:
+			t.Placeholder = tr("Waste Type")
+			t.ShowSuggestions = true
+
+		case inputLocation:
+			t.Placeholder = tr("Waste Location")
+			t.ShowSuggestions = true
+			if m.restrictLocations() {
+				t.Placeholder = tr("Waste Location (left/right to choose)")
+				t.ShowSuggestions = false
+			}
+
+		case inputMethod:
+			t.Placeholder = tr("Disposal Method")
+
+		case inputDisposalDate:
+			t.Placeholder = tr("Disposal Date (YYYY-MM-DD)")
+
+		case inputHazardous:
+			t.Placeholder = tr("Hazardous? (y/n)")
+
+		case inputCost:
+			t.Placeholder = tr("Disposal Cost")
+			t.Validate = validateNumber
+
+		case inputFrequency:
+			t.Placeholder = tr("Collection Frequency (blank if one-off)")
+			t.ShowSuggestions = true
+			t.SetSuggestions(store.Frequencies)
+
+		case inputTags:
+			t.Placeholder = tr("Tags (comma-separated)")
+
+		case inputNotes:
+			t.Placeholder = tr("Notes")
+		}
+
+		m.inputs[i] = t
+	}
+	m.markOpened()
+
+	m.search = textinput.New()
+	m.search.Cursor.Style = m.theme.focused
+	m.search.PlaceholderStyle = m.theme.blurred
+	m.search.CharLimit = 64
+	m.search.Placeholder = m.searchPlaceholder()
+	m.search.Prompt = "/ "
+
+	m.importPath = textinput.New()
+	m.importPath.Cursor.Style = m.theme.focused
+	m.importPath.PlaceholderStyle = m.theme.blurred
+	m.importPath.CharLimit = 256
+	m.importPath.Placeholder = tr("path/to/file.csv or .json")
+	m.importPath.Prompt = tr("Import from: ")
+
+	m.clearConfirm = textinput.New()
+	m.clearConfirm.Cursor.Style = m.theme.focused
+	m.clearConfirm.PlaceholderStyle = m.theme.blurred
+	m.clearConfirm.CharLimit = len(clearWord)
+	m.clearConfirm.Prompt = tr("Type %s to confirm: ", clearWord)
+
+	m.threshold = textinput.New()
+	m.threshold.Cursor.Style = m.theme.focused
+	m.threshold.PlaceholderStyle = m.theme.blurred
+	m.threshold.CharLimit = 32
+	m.threshold.Placeholder = tr(">100, <=5 or 10-50")
+	m.threshold.Prompt = tr("Quantity: ")
+
+	m.restorePath = textinput.New()
+	m.restorePath.Cursor.Style = m.theme.focused
+	m.restorePath.PlaceholderStyle = m.theme.blurred
+	m.restorePath.CharLimit = 256
+	m.restorePath.Placeholder = tr("path/to/backup.bak")
+	m.restorePath.Prompt = tr("Restore from: ")
+
+	if s == nil {
+		return m, nil
+	}
+
+	waste, err := s.Load()
+	if err != nil {
+		m.store = nil
+		return m, fmt.Errorf(tr("error loading waste items: %v"), err)
+	}
+
+	m.waste = waste
+	m.filtered = m.filterItems()
+
+	if err := m.restoreState(); err != nil {
+		return m, fmt.Errorf(tr("error loading settings: %v"), err)
+	}
+
+	return m, nil
+}
+
+// Keys of the settings that carry the view over to the next session.
+const (
+	cursorSetting     = "cursor_id"
+	sortSetting       = "sort_column"
+	sortDescSetting   = "sort_desc"
+	searchSetting     = "search"
+	overdueSetting    = "overdue_only"
+	hazardousSetting  = "hazardous_only"
+	statusSetting     = "status_filter"
+	themeSetting      = "theme"
+	createdSetting    = "created_within"
+	groupSetting      = "group_by_location"
+	columnsSetting    = "hidden_columns"
+	massSetting       = "mass_unit"
+	cursorModeSetting = "cursor_mode"
+	dueSetting        = "due_only"
+	tagSetting        = "tag_filter"
+	quantitySetting   = "quantity_filter"
+	densitySetting    = "density"
+)
+
+// restoreState reapplies the sort, filters and selected item from when the
+// program last exited. Settings that are missing or no longer make sense
+// are left at their defaults.
+func (m *model) restoreState() error {
+	values := make(map[string]string)
+
+	for _, key := range []string{cursorSetting, sortSetting, sortDescSetting, searchSetting, overdueSetting, hazardousSetting, statusSetting, themeSetting, createdSetting, groupSetting, columnsSetting, massSetting, cursorModeSetting, dueSetting, tagSetting, quantitySetting, densitySetting} {
+		value, ok, err := m.store.Setting(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			values[key] = value
+		}
+	}
+
+	m.sortColumn = m.defaultSort
+	for i, name := range sortNames {
+		if name == values[sortSetting] {
+			m.sortColumn = sortColumn(i)
+		}
+	}
+
+	m.sortDesc = m.defaultSortDesc
+	if desc, err := strconv.ParseBool(values[sortDescSetting]); err == nil {
+		m.sortDesc = desc
+	}
+	m.overdueOnly, _ = strconv.ParseBool(values[overdueSetting])
+	m.hazardousOnly, _ = strconv.ParseBool(values[hazardousSetting])
+	m.dueOnly, _ = strconv.ParseBool(values[dueSetting])
+	m.groupLocations, _ = strconv.ParseBool(values[groupSetting])
+	m.comfortable = values[densitySetting] == comfortableDensity
+
+	m.massUnit = ""
+	if _, ok := massUnits[values[massSetting]]; ok {
+		m.massUnit = values[massSetting]
+	}
+
+	m.hiddenColumns = make(map[int]bool)
+	for _, name := range strings.Split(values[columnsSetting], ",") {
+		if i := columnByName(name); i >= 0 {
+			m.hiddenColumns[i] = true
+		}
+	}
+	if len(m.hiddenColumns) == len(columnTitles) {
+		m.hiddenColumns = make(map[int]bool)
+	}
+	m.search.SetValue(values[searchSetting])
+
+	m.statusFilter = ""
+	if store.ValidStatus(values[statusSetting]) {
+		m.statusFilter = values[statusSetting]
+	}
+
+	m.tagFilter = ""
+	for _, tag := range m.tagsInUse() {
+		if tag == values[tagSetting] {
+			m.tagFilter = tag
+		}
+	}
+
+	m.quantityRange = quantityRange{}
+	if r, err := parseQuantityRange(values[quantitySetting]); err == nil {
+		m.quantityRange = r
+	}
+	m.threshold.SetValue(m.quantityRange.expr)
+
+	m.createdWithin = 0
+	if days, err := strconv.Atoi(values[createdSetting]); err == nil && days > 0 {
+		m.createdWithin = days
+	}
+
+	if t, ok := themeByName(values[themeSetting]); ok && !m.noColor {
+		m.setTheme(t)
+	}
+
+	for mode := cursor.CursorBlink; mode <= cursor.CursorHide; mode++ {
+		if mode.String() == values[cursorModeSetting] {
+			m.setCursorMode(mode)
+		}
+	}
+
+	m.filtered = m.filterItems()
+	m.cursor = 0
+
+	if id, err := strconv.Atoi(values[cursorSetting]); err == nil {
+		m.selectID(id)
+	}
+
+	return nil
+}
+
+// saveState remembers the sort, filters and selected item for the next
+// session.
+func (m model) saveState() error {
+	if m.store == nil {
+		return nil
+	}
+
+	settings := map[string]string{
+		sortSetting:       sortNames[m.sortColumn],
+		sortDescSetting:   strconv.FormatBool(m.sortDesc),
+		searchSetting:     m.search.Value(),
+		overdueSetting:    strconv.FormatBool(m.overdueOnly),
+		hazardousSetting:  strconv.FormatBool(m.hazardousOnly),
+		statusSetting:     m.statusFilter,
+		createdSetting:    strconv.Itoa(m.createdWithin),
+		groupSetting:      strconv.FormatBool(m.groupLocations),
+		columnsSetting:    m.hiddenColumnNames(),
+		massSetting:       m.massUnit,
+		cursorModeSetting: m.cursorMode.String(),
+		dueSetting:        strconv.FormatBool(m.dueOnly),
+		tagSetting:        m.tagFilter,
+		quantitySetting:   m.quantityRange.expr,
+		densitySetting:    compactDensity,
+	}
+
+	if m.comfortable {
+		settings[densitySetting] = comfortableDensity
+	}
+
+	if !m.noColor {
+		settings[themeSetting] = m.theme.name
+	}
+
+	if len(m.filtered) > 0 {
+		settings[cursorSetting] = strconv.Itoa(m.waste[m.current()].ID)
+	}
+
+	for key, value := range settings {
+		if err := m.store.SetSetting(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filterItems returns the indices into m.waste of the items that match the
+// search query, in the active sort order, or best match first if there is
+// none. An empty query matches all. Only overdue or hazardous items are
+// kept if overdueOnly or hazardousOnly is set, only recurring items due
+// for collection if dueOnly is set, only items with the status in
+// statusFilter or the tag in tagFilter if they are set, only items with a
+// quantity in quantityRange, as shown, and only items created in the last
+// createdWithin days if it is set. Grouping by location orders the items
+// by location ahead of all of that.
+func (m model) filterItems() []int {
+	query := strings.ToLower(m.search.Value())
+	indices := make([]int, 0, len(m.waste))
+	scores := make(map[int]int)
+	now := time.Now()
+
+	for i, item := range m.waste {
+		if m.overdueOnly && !item.Overdue(now) {
+			continue
+		}
+
+		if m.hazardousOnly && !item.Hazardous {
+			continue
+		}
+
+		if m.dueOnly && !item.Due(now) {
+			continue
+		}
+
+		if m.statusFilter != "" && item.Status != m.statusFilter {
+			continue
+		}
+
+		if m.tagFilter != "" && !item.HasTag(m.tagFilter) {
+			continue
+		}
+
+		if !m.quantityRange.contains(m.display(item).Quantity) {
+			continue
+		}
+
+		if m.createdWithin > 0 && item.CreatedAt.Before(now.AddDate(0, 0, -m.createdWithin)) {
+			continue
+		}
+
+		if query == "" {
+			indices = append(indices, i)
+			continue
+		}
+
+		if score, ok := m.matchItem(query, item); ok {
+			indices = append(indices, i)
+			scores[i] = score
+		}
+	}
+
+	if query != "" && m.sortColumn == sortNone {
+		sort.SliceStable(indices, func(i, j int) bool {
+			return scores[indices[i]] > scores[indices[j]]
+		})
+	}
+
+	m.sortItems(indices)
+
+	if m.groupLocations {
+		m.groupByLocation(indices)
+	}
+
+	return indices
+}
+
+// sortItems orders indices by the active sort column, leaving m.waste as is.
+func (m model) sortItems(indices []int) {
+	if m.sortColumn == sortNone {
+		return
+	}
+
+	less := func(a, b store.Item) bool {
+		switch m.sortColumn {
+		case sortByType:
+			return strings.ToLower(a.WasteType) < strings.ToLower(b.WasteType)
+		case sortByQuantity:
+			return a.Quantity < b.Quantity
+		case sortByUnit:
+			return strings.ToLower(a.Unit) < strings.ToLower(b.Unit)
+		case sortByLocation:
+			return strings.ToLower(a.Location) < strings.ToLower(b.Location)
+		case sortByMethod:
+			return strings.ToLower(a.Method) < strings.ToLower(b.Method)
+		case sortByCreated:
+			return a.CreatedAt.Before(b.CreatedAt)
+		case sortByDisposalDate:
+			return a.DisposalDate < b.DisposalDate
+		case sortByStatus:
+			return statusRank(a.Status) < statusRank(b.Status)
+		default:
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+	}
+
+	// Items are compared as shown, so that with masses converted by U
+	// the quantities and units are in the order they read on screen.
+	sort.SliceStable(indices, func(i, j int) bool {
+		a, b := m.display(m.waste[indices[i]]), m.display(m.waste[indices[j]])
+		if m.sortDesc {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+}
+
+// inForm reports whether the add/edit form is open.
+func (m model) inForm() bool {
+	switch m.inputmode {
+	case addingName, addingQuantity, addingWasteType, addingLocation, addingMethod, editing:
+		return true
+	}
+	return false
+}
+
+// current returns the index into m.waste of the item under the cursor.
+func (m model) current() int {
+	return m.filtered[m.cursor]
+}
+
+// selectID moves the cursor to the item with the given id if it is in
+// the filtered list, and otherwise keeps it in bounds.
+func (m *model) selectID(id int) {
+	for i, index := range m.filtered {
+		if m.waste[index].ID == id {
+			m.cursor = i
+			return
+		}
+	}
+
+	m.clampCursor()
+}
+
+// clampCursor keeps the cursor within the bounds of the filtered list.
+func (m *model) clampCursor() {
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, m.poll())
+}
+
+// Update handles msg and then scrolls the table to keep the cursor in view.
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+
+	if m, ok := next.(model); ok {
+		m.offset, _ = m.visibleRows()
+		return m, cmd
+	}
+
+	return next, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case jumpTimeoutMsg:
+		if msg.seq == m.jumpSeq && m.jump != "" && m.inputmode == normal {
+			return m.resolveJump()
+		}
+		return m, nil
+
+	case pollMsg:
+		return m.updatePoll()
+
+	case notesEditedMsg:
+		return m.updateNotesEdited(msg)
+
+	case addedTimeoutMsg:
+		if msg.seq == m.addedSeq {
+			m.added = nil
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		if m.store == nil {
+			return m, nil
+		}
+		return m.updateMouse(msg)
+
+	case tea.KeyMsg:
+		if m.statusTTL > 0 {
+			m.statusTTL--
+			if m.statusTTL == 0 {
+				m.status = ""
+			}
+		}
+
+		if m.recoverable() {
+			return m.updateRecover(msg)
+		}
+
+		if m.store == nil {
+			switch msg.String() {
+			case "ctrl+c", "q", "esc":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch m.inputmode {
+		case normal:
+			return m.updateNormal(msg)
+		case addingName, addingWasteType, addingLocation, addingMethod, addingQuantity, editing:
+			return m.updateAdding(msg)
+		case confirmingDelete:
+			return m.updateConfirmDelete(msg)
+		case searching:
+			return m.updateSearching(msg)
+		case importing:
+			return m.updateImporting(msg)
+		case viewingDetail:
+			return m.updateDetail(msg)
+		case confirmingQuit:
+			return m.updateConfirmQuit(msg)
+		case viewingHelp:
+			return m.updateHelp(msg)
+		case viewingTrash:
+			return m.updateTrash(msg)
+		case confirmingPurge:
+			return m.updateConfirmPurge(msg)
+		case confirmingMerge:
+			return m.updateConfirmMerge(msg)
+		case viewingAudit:
+			return m.updateAudit(msg)
+		case choosingColumns:
+			return m.updateColumns(msg)
+		case viewingChart:
+			return m.updateChart(msg)
+		case confirmingClear:
+			return m.updateConfirmClear(msg)
+		case filteringQuantity:
+			return m.updateThreshold(msg)
+		case viewingDuplicates:
+			return m.updateDuplicates(msg)
+		case viewingLabel:
+			return m.updateLabel(msg)
+		}
+	}
+
+	cmd := m.updateInputs(msg)
+	return m, cmd
+}
+
+func (m model) updateInputs(msg tea.Msg) tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.inputs))
+
+	for i := range m.inputs {
+		previous, pos := m.inputs[i].Value(), m.inputs[i].Position()
+		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
+
+		// Reject keystrokes that would make the value invalid.
+		if m.inputs[i].Validate != nil && m.inputs[i].Err != nil {
+			m.inputs[i].SetValue(previous)
+			m.inputs[i].SetCursor(pos)
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// fillInputs sets the form inputs to the values of item.
+func (m *model) fillInputs(item store.Item) {
+	m.inputs[inputName].SetValue(item.Name)
+	m.inputs[inputQuantity].SetValue(strconv.FormatFloat(item.Quantity, 'f', -1, 64))
+	m.inputs[inputUnit].SetValue(item.Unit)
+	m.inputs[inputType].SetValue(item.WasteType)
+	m.inputs[inputLocation].SetValue(item.Location)
+	m.inputs[inputMethod].SetValue(item.Method)
+	m.inputs[inputDisposalDate].SetValue(item.DisposalDate)
+	m.inputs[inputHazardous].SetValue("")
+	if item.Hazardous {
+		m.inputs[inputHazardous].SetValue("y")
+	}
+	m.inputs[inputCost].SetValue("")
+	if item.Cost != 0 {
+		m.inputs[inputCost].SetValue(strconv.FormatFloat(item.Cost, 'f', -1, 64))
+	}
+	m.inputs[inputFrequency].SetValue(item.Frequency)
+	m.inputs[inputTags].SetValue(strings.Join(item.Tags, ", "))
+	m.inputs[inputNotes].SetValue(item.Notes)
+	m.markOpened()
+}
+
+// suggest offers the distinct waste types and locations already in use as
+// completions for the type and location inputs.
+func (m *model) suggest() {
+	m.inputs[inputType].SetSuggestions(m.distinct(func(item store.Item) string { return item.WasteType }))
+	if !m.restrictLocations() {
+		m.inputs[inputLocation].SetSuggestions(m.distinct(func(item store.Item) string { return item.Location }))
+	}
+}
+
+// distinct returns the distinct non-empty values of field across the
+// items, most used first.
+func (m model) distinct(field func(store.Item) string) []string {
+	counts := make(map[string]int)
+	var values []string
+
+	for _, item := range m.waste {
+		v := strings.TrimSpace(field(item))
+		if v == "" {
+			continue
+		}
+		if counts[v] == 0 {
+			values = append(values, v)
+		}
+		counts[v]++
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return counts[values[i]] > counts[values[j]]
+	})
+
+	return values
+}
+
+// setTheme switches to t and restyles the text inputs to match.
+func (m *model) setTheme(t theme) {
+	m.theme = t
+
+	restyle := func(input *textinput.Model) {
+		input.Cursor.Style = t.focused
+		input.PlaceholderStyle = t.blurred
+		if input.Focused() {
+			input.PromptStyle = t.focused
+			input.TextStyle = t.focused
+		}
+	}
+
+	for i := range m.inputs {
+		restyle(&m.inputs[i])
+	}
+	restyle(&m.search)
+	restyle(&m.importPath)
+	restyle(&m.restorePath)
+	restyle(&m.clearConfirm)
+	restyle(&m.threshold)
+}
+
+// setCursorMode switches the cursor of every text input to mode.
+func (m *model) setCursorMode(mode cursor.Mode) tea.Cmd {
+	m.cursorMode = mode
+
+	cmds := make([]tea.Cmd, 0, len(m.inputs)+5)
+	for i := range m.inputs {
+		cmds = append(cmds, m.inputs[i].Cursor.SetMode(mode))
+	}
+	cmds = append(cmds,
+		m.search.Cursor.SetMode(mode),
+		m.importPath.Cursor.SetMode(mode),
+		m.restorePath.Cursor.SetMode(mode),
+		m.clearConfirm.Cursor.SetMode(mode),
+		m.threshold.Cursor.SetMode(mode),
+	)
+
+	return tea.Batch(cmds...)
+}
+
+// focusInputs focuses the input at m.focusIndex and blurs the rest.
+func (m model) focusInputs() tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.inputs))
+
+	for i := range m.inputs {
+		if i == m.focusIndex {
+			cmds[i] = m.inputs[i].Focus()
+			m.inputs[i].PromptStyle = m.theme.focused
+			m.inputs[i].TextStyle = m.theme.focused
+			continue
+		}
+
+		m.inputs[i].Blur()
+		m.inputs[i].PromptStyle = noStyle
+		m.inputs[i].TextStyle = noStyle
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key := msg.String(); len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+		return m.addJumpDigit(key)
+	}
+
+	// Any key other than enter abandons a row number being typed.
+	if m.jump != "" {
+		if msg.String() == "enter" {
+			return m.resolveJump()
+		}
+
+		m.jump = ""
+		if msg.String() == "esc" {
+			return m, nil
+		}
+	}
+
+	if m.readOnly && changeKeys[msg.String()] {
+		m.setStatus(tr("The database is open read-only"))
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "ctrl+t":
+		if m.noColor {
+			m.setStatus(tr("Colors are disabled"))
+			break
+		}
+
+		m.setTheme(nextTheme(m.theme))
+		m.setStatus(tr("Theme: %s", m.theme.name))
+
+	case "ctrl+r":
+		mode := m.cursorMode + 1
+
+		if mode > cursor.CursorHide {
+			mode = cursor.CursorBlink
+		}
+
+		return m, m.setCursorMode(mode)
+
+	case "a":
+		m.inputmode = addingName
+		m.focusIndex = 0
+		m.suggest()
+		return m, m.focusInputs()
+
+	case "e", "c":
+		if len(m.filtered) > 0 {
+			m.fillInputs(m.waste[m.current()])
+
+			// c adds a copy of the item rather than editing it.
+			m.inputmode = editing
+			if msg.String() == "c" {
+				m.inputmode = addingName
+			}
+
+			m.focusIndex = 0
+			m.suggest()
+			return m, m.focusInputs()
+		}
+
+	case "d":
+		if len(m.filtered) > 0 || len(m.selected) > 0 {
+			m.inputmode = confirmingDelete
+		}
+
+	case " ":
+		if len(m.filtered) > 0 {
+			m.toggleSelected(m.waste[m.current()].ID)
+		}
+
+	case "enter":
+		if len(m.filtered) > 0 {
+			m.inputmode = viewingDetail
+		}
+
+	case "s":
+		m.sortColumn++
+		if m.sortColumn > sortByStatus {
+			m.sortColumn = sortNone
+		}
+		m.filtered = m.filterItems()
+
+	case "S":
+		m.sortDesc = !m.sortDesc
+		m.filtered = m.filterItems()
+
+	case "x", "alt+x":
+		items := m.exportItems(msg.String() == "alt+x")
+		err := exportCSV(exportPath, items)
+		if err != nil {
+			m.err = fmt.Errorf(tr("failed to export items: %v"), err)
+		} else {
+			m.setStatus(tr("Exported %d items to %s", len(items), exportPath))
+		}
+
+	case "X", "alt+X":
+		items := m.exportItems(msg.String() == "alt+X")
+		err := exportJSON(jsonExportPath, items)
+		if err != nil {
+			m.err = fmt.Errorf(tr("failed to export items: %v"), err)
+		} else {
+			m.setStatus(tr("Exported %d items to %s", len(items), jsonExportPath))
+		}
+
+	case "M", "alt+M":
+		items := m.exportItems(msg.String() == "alt+M")
+		err := m.exportMarkdown(markdownExportPath, items)
+		if err != nil {
+			m.err = fmt.Errorf(tr("failed to export report: %v"), err)
+		} else {
+			m.setStatus(tr("Wrote a report of %d items to %s", len(items), markdownExportPath))
+		}
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		} else if m.wrapNavigation && len(m.filtered) > 0 {
+			m.cursor = len(m.filtered) - 1
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		} else if m.wrapNavigation {
+			m.cursor = 0
+		}
+
+	case "g", "home":
+		m.cursor = 0
+
+	case "G", "end":
+		m.cursor = len(m.filtered) - 1
+		m.clampCursor()
+
+	case "pgdown", "]":
+		m.cursor = min(m.cursor+m.rowsPerPage(), len(m.filtered)-1)
+		m.clampCursor()
+
+	case "pgup", "[":
+		m.cursor = max(m.cursor-m.rowsPerPage(), 0)
+
+	case "y", "Y":
+		if len(m.filtered) == 0 {
+			break
+		}
+
+		if err := m.copyItem(msg.String() == "Y"); err != nil {
+			m.err = fmt.Errorf(tr("failed to copy item: %v"), err)
+		} else {
+			m.setStatus(tr("Copied '%s' to the clipboard", m.waste[m.current()].Name))
+		}
+
+	case "t":
+		m.showStats = !m.showStats
+
+	case "o":
+		m.overdueOnly = !m.overdueOnly
+		m.filtered = m.filterItems()
+		m.clampCursor()
+
+	case "h":
+		m.hazardousOnly = !m.hazardousOnly
+		m.filtered = m.filterItems()
+		m.clampCursor()
+
+	case "R":
+		m.dueOnly = !m.dueOnly
+		m.filtered = m.filterItems()
+		m.clampCursor()
+
+	// Grouping is on L rather than g, which already jumps to the top as
+	// in vim.
+	case "L":
+		id := -1
+		if len(m.filtered) > 0 {
+			id = m.waste[m.current()].ID
+		}
+		m.groupLocations = !m.groupLocations
+		m.filtered = m.filterItems()
+		m.selectID(id)
+
+	case "f":
+		m.statusFilter = nextStatusFilter(m.statusFilter)
+		m.filtered = m.filterItems()
+		m.clampCursor()
+
+	case "#":
+		m.tagFilter = nextTagFilter(m.tagFilter, m.tagsInUse())
+		m.filtered = m.filterItems()
+		m.clampCursor()
+
+	case "w", "m":
+		days := 7
+		if msg.String() == "m" {
+			days = 30
+		}
+
+		if m.createdWithin == days {
+			m.createdWithin = 0
+		} else {
+			m.createdWithin = days
+		}
+		m.filtered = m.filterItems()
+		m.clampCursor()
+
+	case "n":
+		if len(m.filtered) > 0 {
+			return m.advanceStatus()
+		}
+
+	case "+", "shift+up":
+		if len(m.filtered) > 0 {
+			return m.adjustQuantity(m.quantityStep(msg.String()))
+		}
+
+	case "-", "shift+down":
+		if len(m.filtered) > 0 {
+			return m.adjustQuantity(-m.quantityStep(msg.String()))
+		}
+
+	case "u", "ctrl+z":
+		return m.undoChange()
+
+	case "ctrl+y":
+		return m.redoChange()
+
+	case "r":
+		return m.refresh()
+
+	case "i":
+		m.inputmode = importing
+		return m, m.importPath.Focus()
+
+	case "p":
+		return m.nextProfile()
+
+	case "T":
+		return m.openTrash()
+
+	case "A":
+		return m.openAudit()
+
+	case "C":
+		m.inputmode = viewingChart
+
+	case "D":
+		m.inputmode = viewingDuplicates
+
+	case "l":
+		if len(m.filtered) > 0 {
+			m.inputmode = viewingLabel
+		}
+
+	case "U":
+		m.massUnit = nextMassDisplay(m.massUnit)
+
+	case "z":
+		m.comfortable = !m.comfortable
+
+	case "ctrl+d":
+		m.clearConfirm.SetValue("")
+		m.inputmode = confirmingClear
+		return m, m.clearConfirm.Focus()
+
+	case "v":
+		m.columnCursor = 0
+		m.inputmode = choosingColumns
+
+	case "b":
+		return m.backup()
+
+	case "?":
+		m.inputmode = viewingHelp
+
+	case "/":
+		m.inputmode = searching
+		return m, m.search.Focus()
+
+	case ">":
+		m.inputmode = filteringQuantity
+		m.threshold.CursorEnd()
+		return m, m.threshold.Focus()
+
+	case "esc":
+		if len(m.selected) > 0 {
+			m.selected = nil
+			break
+		}
+
+		m.search.SetValue("")
+		m.filtered = m.filterItems()
+		m.clampCursor()
+	}
+
+	return m, nil
+}
+
+// toggleSelected marks or unmarks the item with the given id.
+func (m *model) toggleSelected(id int) {
+	if m.selected[id] {
+		delete(m.selected, id)
+		return
+	}
+
+	if m.selected == nil {
+		m.selected = make(map[int]bool)
+	}
+	m.selected[id] = true
+}
+
+// quantityStep returns how much a +/- key press changes the quantity by;
+// holding shift steps by ten.
+func (m model) quantityStep(key string) float64 {
+	step := m.step
+	if unitStep, ok := m.unitSteps[strings.ToLower(m.waste[m.current()].Unit)]; ok {
+		step = unitStep
+	}
+
+	if strings.HasPrefix(key, "shift+") {
+		return step * 10
+	}
+	return step
+}
+
+// adjustQuantity changes the selected item's quantity by delta, never
+// letting it drop below zero, and saves it.
+func (m model) adjustQuantity(delta float64) (tea.Model, tea.Cmd) {
+	before := m.waste[m.current()]
+	item := before
+	// Rounding away the last few digits keeps steps such as 0.1 from
+	// adding up to quantities like 0.30000000000000004.
+	item.Quantity = max(math.Round((item.Quantity+delta)*1e9)/1e9, 0)
+
+	item, err := m.store.Update(item)
+	if err != nil {
+		m.err = fmt.Errorf(tr("failed to update item: %v"), err)
+		return m, nil
+	}
+
+	m.record(change{kind: changeEdit, items: []store.Item{before}, edited: item})
+
+	m.waste[m.current()] = item
+	m.filtered = m.filterItems()
+	m.selectID(item.ID)
+
+	return m, nil
+}
+
+// advanceStatus moves the selected item on to its next status.
+func (m model) advanceStatus() (tea.Model, tea.Cmd) {
+	before := m.waste[m.current()]
+	item := before
+	item.Status = store.NextStatus(item.Status)
+
+	item, err := m.store.Update(item)
+	if err != nil {
+		m.err = fmt.Errorf(tr("failed to update item: %v"), err)
+		return m, nil
+	}
+
+	m.record(change{kind: changeEdit, items: []store.Item{before}, edited: item})
+
+	m.waste[m.current()] = item
+	m.filtered = m.filterItems()
+	m.selectID(item.ID)
+	m.setStatus(tr("'%s' is now %s", item.Name, item.Status))
+
+	return m, nil
+}
+
+// nextStatusFilter cycles from showing every status through each status in
+// turn and back.
+func nextStatusFilter(filter string) string {
+	if filter == store.Statuses[len(store.Statuses)-1] {
+		return ""
+	}
+	return store.NextStatus(filter)
+}
+
+// tagsInUse returns every tag of the items, in alphabetical order.
+func (m model) tagsInUse() []string {
+	seen := make(map[string]bool)
+	var tags []string
+
+	for _, item := range m.waste {
+		for _, tag := range item.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	sort.Strings(tags)
+	return tags
+}
+
+// nextTagFilter cycles from showing every tag through each of tags in turn
+// and back.
+func nextTagFilter(filter string, tags []string) string {
+	if filter == "" {
+		if len(tags) == 0 {
+			return ""
+		}
+		return tags[0]
+	}
+
+	for i, tag := range tags {
+		if tag == filter && i+1 < len(tags) {
+			return tags[i+1]
+		}
+	}
+	return ""
+}
+
+// statusRank orders statuses by stage rather than alphabetically.
+func statusRank(status string) int {
+	for i, s := range store.Statuses {
+		if s == status {
+			return i
+		}
+	}
+	return len(store.Statuses)
+}
+
+// backupLayout is the timestamp added to the names of backups. It avoids
+// colons so that the names are valid on every platform.
+const backupLayout = "2006-01-02T15-04-05"
+
+// backup copies the open database next to itself with a timestamped name.
+func (m model) backup() (tea.Model, tea.Cmd) {
+	if len(m.profiles) == 0 || m.profiles[m.profile].path == store.Memory {
+		m.err = errors.New(tr("only databases stored in a file can be backed up"))
+		return m, nil
+	}
+
+	path := m.profiles[m.profile].path + "." + time.Now().Format(backupLayout) + ".bak"
+
+	if err := m.store.Backup(path); err != nil {
+		m.err = fmt.Errorf(tr("failed to back up database: %v"), err)
+		return m, nil
+	}
+
+	m.setStatus(tr("Backed up to %s", path))
+	return m, nil
+}
+
+// refresh reloads the items from the database, to pick up changes made by
+// other processes, keeping the cursor on the same item if it is still
+// there.
+func (m model) refresh() (tea.Model, tea.Cmd) {
+	if err := m.reload(); err != nil {
+		m.err = fmt.Errorf(tr("failed to reload items: %v"), err)
+		return m, nil
+	}
+
+	m.err = nil
+	m.setStatus(tr("Refreshed"))
+	return m, nil
+}
+
+// reload loads the items from the store again, keeping the cursor on the
+// same item if it is still there.
+func (m *model) reload() error {
+	waste, err := m.store.Load()
+	if err != nil {
+		return err
+	}
+
+	id := -1
+	if len(m.filtered) > 0 {
+		id = m.waste[m.current()].ID
+	}
+
+	m.waste = waste
+	m.filtered = m.filterItems()
+	m.selectID(id)
+
+	// Forget selected items that were deleted elsewhere.
+	ids := make(map[int]bool, len(waste))
+	for _, item := range waste {
+		ids[item.ID] = true
+	}
+	for selected := range m.selected {
+		if !ids[selected] {
+			delete(m.selected, selected)
+		}
+	}
+
+	return nil
+}
+
+// updateDetail handles keys while the details of an item are shown.
+func (m model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter":
+		m.inputmode = normal
+		m.err = nil
+	case "ctrl+o":
+		if m.readOnly {
+			m.err = store.ErrReadOnly
+			return m, nil
+		}
+		item := m.waste[m.current()]
+		return m, editNotes(item.ID, item.Notes)
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	m.inputmode = normal
+	return m, nil
+}
+
+// detailView renders every field of the selected item without truncation.
+func (m model) detailView() string {
+	item := m.waste[m.current()]
+
+	fields := []struct{ label, value string }{
+		{"Name", item.Name},
+		{"Quantity", strings.TrimSpace(strconv.FormatFloat(item.Quantity, 'f', -1, 64) + " " + item.Unit)},
+		{"Type", item.WasteType},
+		{"Location", item.Location},
+		{"Disposal Method", item.Method},
+		{"Dispose By", item.DisposalDate},
+		{"Hazardous", yesNo(item.Hazardous)},
+		{"Created", item.CreatedAt.Local().Format("2006-01-02 15:04")},
+		{"Updated", item.UpdatedAt.Local().Format("2006-01-02 15:04")},
+		{"Status", item.Status},
+		{"Cost", m.formatCost(item.Cost)},
+		{"Frequency", nextCollection(item)},
+		{"Tags", m.badges(item.Tags)},
+		{"Notes", item.Notes},
+	}
+
+	width := 0
+	for i := range fields {
+		fields[i].label = tr(fields[i].label) + ":"
+		width = max(width, utf8.RuneCountInString(fields[i].label))
+	}
+
+	lines := make([]string, len(fields))
+	for i, f := range fields {
+		lines[i] = m.theme.label.Render(fmt.Sprintf("%-*s", width+1, f.label)) + f.value
+	}
+
+	return m.theme.detail.Render(strings.Join(lines, "\n"))
+}
+
+func (m model) updateSearching(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.inputmode = normal
+		m.search.Blur()
+		return m, nil
+
+	case "esc":
+		m.inputmode = normal
+		m.search.Blur()
+		m.search.SetValue("")
+		m.filtered = m.filterItems()
+		m.clampCursor()
+		return m, nil
+
+	case "ctrl+f":
+		m.substringSearch = !m.substringSearch
+		m.search.Placeholder = m.searchPlaceholder()
+		m.filtered = m.filterItems()
+		m.cursor = 0
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	m.filtered = m.filterItems()
+	m.cursor = 0
+
+	return m, cmd
+}
+
+// clearWord must be typed in full to confirm removing every item.
+const clearWord = "DELETE"
+
+func (m model) updateConfirmClear(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.inputmode = normal
+		m.clearConfirm.Blur()
+		return m, nil
+
+	case "enter":
+		m.inputmode = normal
+		m.clearConfirm.Blur()
+
+		if m.clearConfirm.Value() != clearWord {
+			m.setStatus(tr("Nothing was removed"))
+			return m, nil
+		}
+
+		if err := m.store.Clear(); err != nil {
+			m.err = fmt.Errorf(tr("failed to remove items: %v"), err)
+			return m, nil
+		}
+
+		count := len(m.waste)
+		m.waste = nil
+		m.filtered = m.filterItems()
+		m.cursor = 0
+		m.selected = nil
+		m.forgetChanges()
+		m.setStatus(tr("Removed all %d items", count))
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.clearConfirm, cmd = m.clearConfirm.Update(msg)
+
+	return m, cmd
+}
+
+func (m model) updateImporting(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.inputmode = normal
+		m.importPath.Blur()
+
+		path := strings.TrimSpace(m.importPath.Value())
+
+		var imported, skipped int
+		var err error
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			imported, err = m.importJSON(path)
+		} else {
+			imported, skipped, err = m.importCSV(path)
+		}
+		if err != nil {
+			m.err = fmt.Errorf(tr("failed to import %s: %v"), path, err)
+			return m, nil
+		}
+
+		waste, err := m.store.Load()
+		if err != nil {
+			m.err = fmt.Errorf(tr("failed to reload items: %v"), err)
+			return m, nil
+		}
+
+		added := newIDs(m.waste, waste)
+		m.waste = waste
+		m.filtered = m.filterItems()
+		m.clampCursor()
+		m.importPath.SetValue("")
+		m.setStatus(tr("Imported %d items, skipped %d rows", imported, skipped))
+		return m, m.markAdded(added)
+
+	case "esc":
+		m.inputmode = normal
+		m.importPath.Blur()
+		m.importPath.SetValue("")
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.importPath, cmd = m.importPath.Update(msg)
+
+	return m, cmd
+}
+
+func (m model) updateConfirmQuit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "y" {
+		return m, tea.Quit
+	}
+
+	m.inputmode = m.quitFrom
+	return m, nil
+}
+
+// markOpened records the values of the form inputs as those it was
+// opened with, which hasUnsavedInput compares against.
+func (m *model) markOpened() {
+	m.opened = make([]string, len(m.inputs))
+	for i := range m.inputs {
+		m.opened[i] = m.inputs[i].Value()
+	}
+}
+
+// hasUnsavedInput reports whether any field of the form has been changed
+// since it was opened.
+func (m model) hasUnsavedInput() bool {
+	for i := range m.inputs {
+		if m.inputs[i].Value() != m.opened[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (m model) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.inputmode = normal
+
+	if msg.String() != "y" {
+		return m, nil
+	}
+
+	if len(m.selected) > 0 {
+		return m.deleteSelected()
+	}
+
+	index := m.current()
+	err := m.store.Delete(m.waste[index].ID)
+
+	if err != nil {
+		m.err = fmt.Errorf(tr("failed to delete item: %v"), err)
+	} else {
+		deleted := m.waste[index]
+		m.record(change{kind: changeDelete, items: []store.Item{deleted}})
+
+		m.waste = append(m.waste[:index], m.waste[index+1:]...)
+		m.filtered = m.filterItems()
+		m.clampCursor()
+		m.setStatus(tr("Moved '%s' to the trash", deleted.Name))
+	}
+
+	return m, nil
+}
+
+// deleteSelected moves every selected item to the trash at once and
+// clears the selection.
+func (m model) deleteSelected() (tea.Model, tea.Cmd) {
+	ids := make([]int, 0, len(m.selected))
+	for id := range m.selected {
+		ids = append(ids, id)
+	}
+
+	if err := m.store.DeleteAll(ids); err != nil {
+		m.err = fmt.Errorf(tr("failed to delete items: %v"), err)
+		return m, nil
+	}
+
+	var deleted []store.Item
+	kept := m.waste[:0]
+	for _, item := range m.waste {
+		if m.selected[item.ID] {
+			deleted = append(deleted, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+
+	m.waste = kept
+	m.selected = nil
+	m.record(change{kind: changeDelete, items: deleted})
+	m.filtered = m.filterItems()
+	m.clampCursor()
+	m.setStatus(tr("Moved %d items to the trash", len(ids)))
+
+	return m, nil
+}
+
+func (m model) updateAdding(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch s := msg.String(); s {
+	case "ctrl+c":
+		if !m.hasUnsavedInput() {
+			return m, tea.Quit
+		}
+
+		m.quitFrom = m.inputmode
+		m.inputmode = confirmingQuit
+		return m, nil
+
+	case "ctrl+o":
+		return m, editNotes(0, m.inputs[inputNotes].Value())
+
+	case "tab", "shift+tab", "up", "down":
+		// Tab on an input with suggestions, such as the type or
+		// location, completes it first, taking the suggestion's spelling.
+		if s == "tab" && m.focusIndex < len(m.inputs) && m.inputs[m.focusIndex].ShowSuggestions {
+			input := &m.inputs[m.focusIndex]
+			if suggestion := input.CurrentSuggestion(); suggestion != "" && suggestion != input.Value() {
+				input.SetValue(suggestion)
+				input.CursorEnd()
+				return m, nil
+			}
+		}
+
+		if s == "up" || s == "shift+tab" {
+			m.focusIndex--
+		} else {
+			m.focusIndex++
+		}
+
+		// The submit button sits after the last input.
+		if m.focusIndex > len(m.inputs) {
+			m.focusIndex = 0
+		} else if m.focusIndex < 0 {
+			m.focusIndex = len(m.inputs)
+		}
+
+		return m, m.focusInputs()
+
+	case "enter":
+		if m.focusIndex < len(m.inputs)-1 {
+			m.focusIndex++
+			return m, m.focusInputs()
+		} else {
+			return m.submitWasteItem()
+		}
+
+	case "esc":
+		m.inputmode = normal
+		m.resetInputs()
+		return m, nil
+	}
+
+	// The location is chosen from the allowed ones rather than typed.
+	if m.focusIndex == inputLocation && m.restrictLocations() {
+		switch msg.String() {
+		case "left":
+			m.cycleLocation(-1)
+		case "right":
+			m.cycleLocation(1)
+		}
+		m.revalidate()
+		return m, nil
+	}
+
+	cmd := m.updateInputs(msg)
+	m.revalidate()
+	return m, cmd
+}
+
+// parseQuantity parses a quantity entered in the form, which must be a
+// number greater than zero.
+func parseQuantity(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New(tr("quantity is required"))
+	}
+
+	// ParseFloat also takes NaN and Inf, which are no use as quantities.
+	quantity, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return 0, errors.New(tr("quantity must be a number"))
+	}
+
+	if quantity <= 0 {
+		return 0, errors.New(tr("quantity must be greater than zero"))
+	}
+
+	return quantity, nil
+}
+
+// validateNumber accepts anything that is, or could be typed into, a
+// decimal number, so that letters never make it into numeric fields.
+func validateNumber(s string) error {
+	dot := false
+
+	for _, r := range s {
+		switch {
+		case r == '.' && !dot:
+			dot = true
+		case r < '0' || r > '9':
+			return errors.New(tr("must be a number"))
+		}
+	}
+
+	return nil
+}
+
+// parseCost parses an optional cost. An empty cost means zero.
+func parseCost(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	cost, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.New(tr("cost must be a number"))
+	}
+
+	if cost < 0 {
+		return 0, errors.New(tr("cost cannot be negative"))
+	}
+
+	return cost, nil
+}
+
+// formatCost renders a cost with two decimals and the currency symbol.
+func (m model) formatCost(cost float64) string {
+	return fmt.Sprintf("%s%.2f", m.currency, cost)
+}
+
+// formatQuantity renders a quantity with m.precision decimals and commas
+// between the thousands.
+func (m model) formatQuantity(quantity float64) string {
+	s := strconv.FormatFloat(quantity, 'f', m.precision, 64)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	if hasFrac {
+		b.WriteString("." + frac)
+	}
+
+	return b.String()
+}
+
+// parseYesNo parses a y/n answer. An empty answer means no.
+func parseYesNo(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "y", "yes":
+		return true, nil
+	case "", "n", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf(tr("invalid answer %q"), s)
+}
+
+// badges renders tags as a row of badges.
+func (m model) badges(tags []string) string {
+	rendered := make([]string, len(tags))
+	for i, tag := range tags {
+		rendered[i] = m.theme.tag.Render(tag)
+	}
+	return strings.Join(rendered, " ")
+}
+
+// nextCollection describes how often item is collected and when next.
+func nextCollection(item store.Item) string {
+	if item.Frequency == "" {
+		return tr("one-off")
+	}
+	return tr("%s, next due %s", item.Frequency, item.NextCollection().Local().Format(store.DateLayout))
+}
+
+// yesNo renders b for the screen, in the language of the interface.
+func yesNo(b bool) string {
+	return tr(englishYesNo(b))
+}
+
+// englishYesNo renders b for the subcommands and exports, which are in
+// English whatever the language of the interface.
+func englishYesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// validateField checks the value of the form input i, returning why it
+// cannot be saved if it cannot.
+func (m model) validateField(i int) error {
+	value := strings.TrimSpace(m.inputs[i].Value())
+
+	switch i {
+	case inputName:
+		if value == "" {
+			return errors.New(tr("name is required"))
+		}
+
+	case inputQuantity:
+		_, err := parseQuantity(value)
+		return err
+
+	case inputType:
+		if value == "" {
+			return errors.New(tr("waste type is required"))
+		}
+
+	case inputLocation:
+		if _, ok := m.allowedLocation(value); value != "" && m.restrictLocations() && !ok {
+			return fmt.Errorf(tr("location must be one of %s"), strings.Join(m.locations, ", "))
+		}
+
+	case inputDisposalDate:
+		if value == "" {
+			return nil
+		}
+		if _, err := time.Parse(store.DateLayout, value); err != nil {
+			return errors.New(tr("disposal date must be YYYY-MM-DD"))
+		}
+
+	case inputHazardous:
+		if _, err := parseYesNo(value); err != nil {
+			return errors.New(tr("hazardous must be y or n"))
+		}
+
+	case inputCost:
+		_, err := parseCost(value)
+		return err
+
+	case inputFrequency:
+		if !store.ValidFrequency(strings.ToLower(value)) {
+			return fmt.Errorf(tr("frequency must be one of %s, or blank"), strings.Join(store.Frequencies, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateForm checks every form input, noting the error of each invalid
+// one to show beneath it and focusing the first. It reports whether they
+// are all valid.
+func (m *model) validateForm() bool {
+	m.fieldErrs = make(map[int]string)
+
+	for i := len(m.inputs) - 1; i >= 0; i-- {
+		if err := m.validateField(i); err != nil {
+			m.fieldErrs[i] = err.Error()
+			m.focusIndex = i
+		}
+	}
+
+	return len(m.fieldErrs) == 0
+}
+
+// revalidate clears the errors of the inputs that have since been fixed,
+// and updates those of the rest.
+func (m *model) revalidate() {
+	for i := range m.fieldErrs {
+		if err := m.validateField(i); err != nil {
+			m.fieldErrs[i] = err.Error()
+		} else {
+			delete(m.fieldErrs, i)
+		}
+	}
+}
+
+func (m model) submitWasteItem() (tea.Model, tea.Cmd) {
+	if !m.validateForm() {
+		return m, m.focusInputs()
+	}
+
+	// The inputs were validated above, so these cannot fail.
+	quantity, _ := parseQuantity(m.inputs[inputQuantity].Value())
+	hazardous, _ := parseYesNo(m.inputs[inputHazardous].Value())
+	cost, _ := parseCost(m.inputs[inputCost].Value())
+
+	newItem := store.Item{
+		Name:         strings.TrimSpace(m.inputs[inputName].Value()),
+		Quantity:     quantity,
+		Unit:         strings.TrimSpace(m.inputs[inputUnit].Value()),
+		WasteType:    strings.TrimSpace(m.inputs[inputType].Value()),
+		Location:     strings.TrimSpace(m.inputs[inputLocation].Value()),
+		Method:       strings.TrimSpace(m.inputs[inputMethod].Value()),
+		DisposalDate: strings.TrimSpace(m.inputs[inputDisposalDate].Value()),
+		Hazardous:    hazardous,
+		Notes:        strings.TrimSpace(m.inputs[inputNotes].Value()),
+		Cost:         cost,
+		Frequency:    strings.ToLower(strings.TrimSpace(m.inputs[inputFrequency].Value())),
+		Tags:         store.ParseTags(m.inputs[inputTags].Value()),
+	}
+
+	if location, ok := m.allowedLocation(newItem.Location); ok {
+		newItem.Location = location
+	}
+
+	if m.inputmode == editing {
+		old := m.waste[m.current()]
+		newItem.ID = old.ID
+		newItem.CreatedAt = old.CreatedAt
+		newItem.Status = old.Status
+		newItem.Version = old.Version
+
+		newItem, err := m.store.Update(newItem)
+		if err != nil {
+			m.err = fmt.Errorf(tr("failed to update item: %v"), err)
+			return m, nil
+		}
+
+		m.record(change{kind: changeEdit, items: []store.Item{old}, edited: newItem})
+
+		m.waste[m.current()] = newItem
+		m.filtered = m.filterItems()
+		m.selectID(newItem.ID)
+		m.setStatus(tr("Updated '%s'", newItem.Name))
+		m.inputmode = normal
+		m.resetInputs()
+		return m, nil
+	}
+
+	if m.checkDuplicates {
+		if i := m.findDuplicate(newItem); i >= 0 {
+			m.pending = &newItem
+			m.duplicate = i
+			m.inputmode = confirmingMerge
+			return m, nil
+		}
+	}
+
+	return m.addItem(newItem)
+}
+
+// addItem saves a new item and closes the form.
+func (m model) addItem(item store.Item) (tea.Model, tea.Cmd) {
+	item, err := m.store.Add(item)
+	if err != nil {
+		m.err = fmt.Errorf(tr("failed to add item: %v"), err)
+		return m, nil
+	}
+
+	m.record(change{kind: changeAdd, items: []store.Item{item}})
+
+	m.waste = append(m.waste, item)
+	m.filtered = m.filterItems()
+	m.setStatus(tr("Added '%s'", item.Name))
+	m.inputmode = normal
+	m.resetInputs()
+
+	return m, m.markAdded([]int{item.ID})
+}
+
+// findDuplicate returns the index in m.waste of an item with the same name,
+// type, location and unit as item, ignoring case, or -1 if there is none.
+// The unit has to match too so that the quantities can be merged.
+func (m model) findDuplicate(item store.Item) int {
+	for i, other := range m.waste {
+		if strings.EqualFold(other.Name, item.Name) &&
+			strings.EqualFold(other.WasteType, item.WasteType) &&
+			strings.EqualFold(other.Location, item.Location) &&
+			strings.EqualFold(other.Unit, item.Unit) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (m model) updateConfirmMerge(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "m":
+		return m.mergePending()
+
+	case "a":
+		item := *m.pending
+		m.pending = nil
+		return m.addItem(item)
+
+	case "esc":
+		m.pending = nil
+		m.inputmode = addingName
+		return m, nil
+
+	case "ctrl+c":
+		m.quitFrom = addingName
+		m.inputmode = confirmingQuit
+	}
+
+	return m, nil
+}
+
+// mergePending adds the quantity of the pending item to its duplicate
+// instead of saving it as a new item.
+func (m model) mergePending() (tea.Model, tea.Cmd) {
+	before := m.waste[m.duplicate]
+	item := before
+	item.Quantity += m.pending.Quantity
+
+	item, err := m.store.Update(item)
+	if err != nil {
+		m.err = fmt.Errorf(tr("failed to merge item: %v"), err)
+		return m, nil
+	}
+
+	m.record(change{kind: changeEdit, items: []store.Item{before}, edited: item})
+
+	m.waste[m.duplicate] = item
+	m.pending = nil
+	m.filtered = m.filterItems()
+	m.selectID(item.ID)
+	m.setStatus(tr("Merged into '%s'", item.Name))
+	m.inputmode = normal
+	m.resetInputs()
+
+	return m, nil
+}
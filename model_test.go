@@ -0,0 +1,234 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// newTestModel returns a model on a fresh in-memory database.
+func newTestModel(t *testing.T) model {
+	t.Helper()
+
+	db, err := store.Open(store.Memory)
+	if err != nil {
+		t.Fatalf("opening the database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m, err := initialModel(db, config{})
+	if err != nil {
+		t.Fatalf("creating the model: %v", err)
+	}
+	return m
+}
+
+// submit fills in the add form with an item called name and submits it.
+func submit(t *testing.T, m model, name string) model {
+	t.Helper()
+
+	m.inputmode = addingName
+	m.resetInputs()
+	m.inputs[inputName].SetValue(name)
+	m.inputs[inputQuantity].SetValue("2.5")
+	m.inputs[inputUnit].SetValue("kg")
+	m.inputs[inputType].SetValue("Plastic")
+	m.inputs[inputLocation].SetValue("Dock")
+	m.inputs[inputMethod].SetValue("Recycle")
+
+	next, _ := m.submitWasteItem()
+	m = next.(model)
+	if m.err != nil {
+		t.Fatalf("submitting %q: %v", name, m.err)
+	}
+	if m.inputmode != normal {
+		t.Fatalf("submitting %q left the form open: %v", name, m.fieldErrs)
+	}
+	return m
+}
+
+func TestSubmitWasteItemAddsToList(t *testing.T) {
+	m := newTestModel(t)
+	before := len(m.waste)
+
+	m = submit(t, m, "Bottles")
+	m = submit(t, m, "Crates")
+
+	if got := len(m.waste); got != before+2 {
+		t.Fatalf("len(m.waste) = %d, want %d", got, before+2)
+	}
+
+	items, err := m.store.Load()
+	if err != nil {
+		t.Fatalf("loading the items: %v", err)
+	}
+
+	last := m.waste[len(m.waste)-1]
+	var stored store.Item
+	for _, item := range items {
+		if item.Name == "Crates" {
+			stored = item
+		}
+	}
+	if stored.ID == 0 {
+		t.Fatal("the store has no item called Crates")
+	}
+	if last.ID != stored.ID || last.Name != "Crates" {
+		t.Errorf("last item is %q with id %d, want %q with id %d", last.Name, last.ID, "Crates", stored.ID)
+	}
+}
+
+// press sends each of keys to m in turn.
+func press(m model, keys ...tea.KeyMsg) model {
+	for _, key := range keys {
+		next, _ := m.Update(key)
+		m = next.(model)
+	}
+	return m
+}
+
+// runes is the key press of typing s.
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestDeletingEveryItemLeavesAUsableList(t *testing.T) {
+	m := newTestModel(t)
+	m = submit(t, m, "Bottles")
+	m = submit(t, m, "Crates")
+
+	for len(m.waste) > 0 {
+		before := len(m.waste)
+		m = press(m, runes("d"), runes("y"))
+		if len(m.waste) != before-1 {
+			t.Fatalf("deleting left %d items, want %d (error %v)", len(m.waste), before-1, m.err)
+		}
+	}
+
+	m = press(m,
+		tea.KeyMsg{Type: tea.KeyUp},
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d on an empty list, want 0", m.cursor)
+	}
+	if m.inputmode == viewingDetail {
+		t.Error("enter on an empty list opened the details")
+	}
+
+	// Drawing the empty list must not panic either.
+	_ = m.View()
+}
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"", 0, true},
+		{"   ", 0, true},
+		{"-3", 0, true},
+		{"0", 0, true},
+		{"abc", 0, true},
+		{"NaN", 0, true},
+		{"Inf", 0, true},
+		{"-Inf", 0, true},
+		{"12.75", 12.75, false},
+		{" 4 ", 4, false},
+	}
+
+	for _, tt := range tests {
+		got, err := parseQuantity(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseQuantity(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseQuantity(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestQuittingAnUnchangedEditDoesNotConfirm(t *testing.T) {
+	m := newTestModel(t)
+	m = submit(t, m, "Bottles")
+
+	m = press(m, runes("e"))
+	if m.inputmode != editing {
+		t.Fatalf("e opened mode %v, want the edit form", m.inputmode)
+	}
+	if m.hasUnsavedInput() {
+		t.Error("an edit form just opened has unsaved input")
+	}
+
+	m = press(m, runes("x"))
+	if !m.hasUnsavedInput() {
+		t.Error("typing into the edit form left no unsaved input")
+	}
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if m = next.(model); m.inputmode != confirmingQuit {
+		t.Errorf("ctrl+c on a changed form went to mode %v, want the quit confirmation", m.inputmode)
+	}
+}
+
+func TestSortByQuantityFollowsDisplayedMasses(t *testing.T) {
+	m := newTestModel(t)
+	m.waste = []store.Item{
+		{ID: 1, Name: "Heavy", Quantity: 2, Unit: "kg"},
+		{ID: 2, Name: "Light", Quantity: 500, Unit: "g"},
+	}
+	m.sortColumn = sortByQuantity
+	m.massUnit = "kg"
+
+	m.filtered = m.filterItems()
+	if got := m.waste[m.filtered[0]].Name; got != "Light" {
+		t.Errorf("first item sorted by quantity in kg is %q, want %q", got, "Light")
+	}
+}
+
+func TestGroupedTableFitsOnScreen(t *testing.T) {
+	m := newTestModel(t)
+	for i := range 40 {
+		m.waste = append(m.waste, store.Item{ID: i + 1, Name: "Drum", Quantity: 1, Location: string(rune('A'+i%26)) + string(rune('a'+i/26))})
+	}
+	m.groupLocations = true
+	m.height = 30
+	m.filtered = m.filterItems()
+
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyUp}} {
+		for range len(m.filtered) {
+			m = press(m, key)
+
+			start, end := m.visibleRows()
+			if m.cursor < start || m.cursor >= end {
+				t.Fatalf("cursor %d is outside the rows shown, %d to %d", m.cursor, start, end)
+			}
+			if lines := m.tableLines(start, end); lines > m.rowsPerPage() {
+				t.Fatalf("with the cursor on %d, rows %d to %d take %d lines, more than the %d there is room for", m.cursor, start, end, lines, m.rowsPerPage())
+			}
+		}
+	}
+}
+
+func TestHintLineComesFromKeymap(t *testing.T) {
+	m := newTestModel(t)
+	m.width = 200
+
+	want := "enter details · a add · e edit · d delete · / search · ? help · q, ctrl+c quit"
+	if got := m.hintLine(); !strings.Contains(got, want) {
+		t.Errorf("list hint line = %q, want it to read %q", got, want)
+	}
+
+	m.readOnly = true
+	want = "enter details · / search · ? help · q, ctrl+c quit"
+	if got := m.hintLine(); !strings.Contains(got, want) {
+		t.Errorf("read-only list hint line = %q, want it to read %q", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickTime is how soon a second click on the same row must follow
+// the first to open its details.
+const doubleClickTime = 400 * time.Millisecond
+
+// updateMouse moves the cursor to the clicked row, opens its details on a
+// double click and scrolls with the wheel. Clicking a column heading sorts
+// by that column, or reverses the sort if it is already the sort column.
+// Mouse input is ignored outside the list.
+func (m model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.inputmode != normal || len(m.filtered) == 0 {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case tea.MouseButtonWheelDown:
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case tea.MouseButtonLeft:
+	default:
+		return m, nil
+	}
+
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	if msg.Y == m.tableTop()-1 {
+		if i, ok := m.columnAt(msg.X); ok {
+			if column := sortColumn(i + 1); column == m.sortColumn {
+				m.sortDesc = !m.sortDesc
+			} else {
+				m.sortColumn, m.sortDesc = column, false
+			}
+			m.filtered = m.filterItems()
+		}
+		return m, nil
+	}
+
+	row, ok := m.rowAt(msg.Y)
+	if !ok {
+		return m, nil
+	}
+
+	now := time.Now()
+	double := row == m.clickRow && now.Sub(m.clickTime) < doubleClickTime
+
+	m.cursor = row
+	m.jump = ""
+
+	if double {
+		m.clickTime = time.Time{}
+		m.inputmode = viewingDetail
+		return m, nil
+	}
+
+	m.clickRow, m.clickTime = row, now
+	return m, nil
+}
+
+// rowAt returns the index into filtered of the table row drawn on screen
+// line y, if there is one. Both lines of a comfortable row belong to it.
+func (m model) rowAt(y int) (int, bool) {
+	start, end := m.visibleRows()
+
+	line := m.tableTop()
+	for row := start; row < end; row++ {
+		if _, ok := m.groupHeader(row, start); ok {
+			line++
+		}
+		if y >= line && y < line+m.rowLines() {
+			return row, true
+		}
+		line += m.rowLines()
+	}
+
+	return 0, false
+}
+
+// columnAt returns the index into columnTitles of the table column drawn
+// at screen column x, if there is one. The separators between columns
+// belong to neither.
+func (m model) columnAt(x int) (int, bool) {
+	x -= m.theme.title.GetMarginLeft() + m.theme.title.GetPaddingLeft()
+
+	pos := 0
+	for i, width := range m.columnWidths() {
+		if m.hiddenColumns[i] {
+			continue
+		}
+		if x >= pos && x < pos+width {
+			return i, true
+		}
+		pos += width + len(" | ")
+	}
+
+	return 0, false
+}
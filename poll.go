@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// minRefresh is the shortest interval the items may be reloaded at, so
+// that polling cannot keep the database busy.
+const minRefresh = time.Second
+
+// validRefresh checks a reload interval, which is either zero to turn
+// polling off or at least minRefresh.
+func validRefresh(d time.Duration) error {
+	if d != 0 && d < minRefresh {
+		return fmt.Errorf("must be at least %v, or 0 to turn it off", minRefresh)
+	}
+	return nil
+}
+
+// pollMsg is sent every m.pollInterval to reload the items.
+type pollMsg struct{}
+
+// poll schedules the next reload, unless polling is off.
+func (m model) poll() tea.Cmd {
+	if m.pollInterval <= 0 {
+		return nil
+	}
+
+	return tea.Tick(m.pollInterval, func(time.Time) tea.Msg {
+		return pollMsg{}
+	})
+}
+
+// updatePoll reloads the items and schedules the next reload. Reloads are
+// skipped while anything other than the list is shown, as the other modes
+// hold on to positions in m.waste, and a failed reload is shown as an error
+// without stopping the polling.
+func (m model) updatePoll() (tea.Model, tea.Cmd) {
+	if m.store != nil && m.inputmode == normal {
+		if err := m.reload(); err != nil {
+			m.err = fmt.Errorf(tr("failed to reload items: %v"), err)
+		}
+	}
+
+	return m, m.poll()
+}
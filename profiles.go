@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// profile is a named database, so that several sites can be tracked
+// separately.
+type profile struct {
+	name string
+	path string
+}
+
+// defaultProfilesPath returns the profiles file in the user's config
+// directory, or an empty string if there is none.
+func defaultProfilesPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "wmtui", "profiles")
+}
+
+// loadProfiles reads the profiles file at path. Each line holds a profile
+// as name=path; blank lines and lines starting with # are ignored. A
+// missing file means no profiles are configured.
+func loadProfiles(path string) ([]profile, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var profiles []profile
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, dbPath, ok := strings.Cut(line, "=")
+		name, dbPath = strings.TrimSpace(name), strings.TrimSpace(dbPath)
+		if !ok || name == "" || dbPath == "" {
+			return nil, fmt.Errorf(tr("%s:%d: expected name=path"), path, n)
+		}
+
+		profiles = append(profiles, profile{name: name, path: dbPath})
+	}
+
+	return profiles, scanner.Err()
+}
+
+// nextProfile switches to the next profile's database, keeping the current
+// one open if the next cannot be loaded.
+func (m model) nextProfile() (tea.Model, tea.Cmd) {
+	if len(m.profiles) < 2 {
+		m.setStatus(tr("No other profiles configured"))
+		return m, nil
+	}
+
+	next := (m.profile + 1) % len(m.profiles)
+	p := m.profiles[next]
+
+	s, err := openStore(p.path, m.readOnly)
+	if err != nil {
+		m.err = fmt.Errorf(tr("failed to open profile %s: %v"), p.name, err)
+		return m, nil
+	}
+
+	waste, err := s.Load()
+	if err != nil {
+		s.Close()
+		m.err = fmt.Errorf(tr("failed to load profile %s: %v"), p.name, err)
+		return m, nil
+	}
+
+	if err := m.saveState(); err != nil {
+		m.err = fmt.Errorf(tr("failed to save settings: %v"), err)
+	}
+	m.store.Close()
+
+	m.store = s
+	m.profile = next
+	m.waste = waste
+	m.forgetChanges()
+	m.selected = nil
+
+	if err := m.restoreState(); err != nil {
+		m.err = fmt.Errorf(tr("failed to load settings: %v"), err)
+		return m, nil
+	}
+
+	m.setStatus(tr("Switched to profile %s", p.name))
+	return m, nil
+}
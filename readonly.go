@@ -0,0 +1,52 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// openStore opens the database at path, without writing to it if
+// readOnly is set.
+func openStore(path string, readOnly bool) (store.Store, error) {
+	if readOnly {
+		return store.OpenReadOnly(path)
+	}
+
+	db, err := store.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// changeKeys are the keys of the list that change items, which do nothing
+// in read-only mode.
+var changeKeys = map[string]bool{
+	"a": true, "e": true, "c": true, "d": true, "u": true, "n": true, "i": true,
+	"+": true, "-": true, "shift+up": true, "shift+down": true, "ctrl+d": true,
+	"ctrl+z": true, "ctrl+y": true,
+}
+
+// changeBindings are the keybindings in the help that change items, by
+// mode, which are left out of it in read-only mode. Modes listed without
+// any keybindings only make changes, and are left out whole.
+var changeBindings = map[string][]string{
+	"List":            {"a", "e", "c", "d", "u, ctrl+z", "ctrl+y", "ctrl+d", "+/-", "shift+up/down", "n", "i"},
+	"Trash":           {"r", "D"},
+	"Details":         {"ctrl+o"},
+	"Add / Edit":      nil,
+	"Duplicate Found": nil,
+	"Import":          nil,
+}
+
+// hideGroup reports whether the help leaves out the whole of group.
+func (m model) hideGroup(group keyGroup) bool {
+	bindings, ok := changeBindings[group.mode]
+	return m.readOnly && ok && bindings == nil
+}
+
+// hideBinding reports whether the help leaves out binding of group.
+func (m model) hideBinding(group keyGroup, binding keyHelp) bool {
+	return m.readOnly && slices.Contains(changeBindings[group.mode], binding.keys)
+}
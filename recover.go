@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// recoverable reports whether the program started without a store because
+// the database file could not be read, which can be replaced.
+func (m model) recoverable() bool {
+	return m.store == nil && errors.Is(m.err, store.ErrCorrupt) && len(m.profiles) > 0 &&
+		m.profiles[m.profile].path != store.Memory && !m.readOnly
+}
+
+// updateRecover offers to replace an unreadable database with a fresh one
+// or a backup. The unreadable file is kept, renamed, in case it can be
+// salvaged by other means.
+func (m model) updateRecover(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.restorePath.Focused() {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+
+		case "esc":
+			m.restorePath.Blur()
+			m.restorePath.SetValue("")
+			return m, nil
+
+		case "enter":
+			path := strings.TrimSpace(m.restorePath.Value())
+			if path == "" {
+				return m, nil
+			}
+			return m.replaceDatabase(path)
+		}
+
+		var cmd tea.Cmd
+		m.restorePath, cmd = m.restorePath.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		return m, tea.Quit
+
+	case "n":
+		return m.replaceDatabase("")
+
+	case "b":
+		return m, m.restorePath.Focus()
+	}
+
+	return m, nil
+}
+
+// replaceDatabase moves the unreadable database aside and opens a new one
+// in its place, which is a copy of backup unless that is empty.
+func (m model) replaceDatabase(backup string) (tea.Model, tea.Cmd) {
+	path := m.profiles[m.profile].path
+	aside := path + ".damaged-" + time.Now().Format(backupLayout)
+
+	// The backup is checked on a copy, as opening it migrates it, and
+	// only then put in place of the damaged file.
+	restoring := path + ".restoring"
+	if backup != "" {
+		if _, err := os.Stat(backup); err != nil {
+			m.restoreErr = err
+			return m, nil
+		}
+
+		os.Remove(restoring)
+		if err := copyFile(backup, restoring); err != nil {
+			m.restoreErr = fmt.Errorf(tr("failed to copy %s: %v"), backup, err)
+			return m, nil
+		}
+
+		s, err := store.Open(restoring)
+		if err != nil {
+			os.Remove(restoring)
+			m.restoreErr = fmt.Errorf(tr("cannot use %s: %v"), backup, err)
+			return m, nil
+		}
+		s.Close()
+	}
+
+	// The journal files belong to the damaged database, and would be
+	// applied to the new one if left behind.
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		err := os.Rename(path+suffix, aside+suffix)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			m.restoreErr = fmt.Errorf(tr("failed to move %s aside: %v"), path+suffix, err)
+			return m, nil
+		}
+	}
+
+	if backup != "" {
+		if err := os.Rename(restoring, path); err != nil {
+			m.restoreErr = fmt.Errorf(tr("failed to restore %s: %v"), backup, err)
+			return m, nil
+		}
+	}
+
+	s, err := store.Open(path)
+	if err != nil {
+		m.restoreErr = err
+		return m, nil
+	}
+
+	waste, err := s.Load()
+	if err != nil {
+		s.Close()
+		m.restoreErr = fmt.Errorf(tr("error loading waste items: %v"), err)
+		return m, nil
+	}
+
+	m.store = s
+	m.waste = waste
+	m.restorePath.Blur()
+	m.err = nil
+	m.restoreErr = nil
+
+	if err := m.restoreState(); err != nil {
+		m.err = fmt.Errorf(tr("error loading settings: %v"), err)
+		return m, nil
+	}
+
+	if backup != "" {
+		m.setStatus(tr("Restored %s; the damaged file is now %s", backup, aside))
+	} else {
+		m.setStatus(tr("Started a new database; the damaged file is now %s", aside))
+	}
+
+	return m, nil
+}
+
+// copyFile copies the file at src to dst, which must not exist yet.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// recoverView explains that the database cannot be read and how to
+// replace it.
+func (m model) recoverView() string {
+	var b strings.Builder
+
+	path := m.profiles[m.profile].path
+
+	b.WriteString(m.theme.err.Render(tr("The database %s cannot be read: %v", path, m.err)))
+	b.WriteString("\n\n")
+	b.WriteString(tr("It may have been cut short, for example when the disk filled up. You can start over\n" +
+		"with an empty database, or with a copy of an earlier backup.\n" +
+		"Either way the damaged file is kept, renamed, next to the new one."))
+	b.WriteString("\n\n")
+
+	if m.restorePath.Focused() {
+		b.WriteString(m.restorePath.View())
+		b.WriteString("\n\n")
+		b.WriteString(m.theme.help().Render(tr("Press (enter) to restore the backup, (esc) to cancel")))
+	} else {
+		b.WriteString(m.theme.help().Render(tr("Press (n) for a new database, (b) to restore a backup, (q) to quit")))
+	}
+
+	if m.restoreErr != nil {
+		b.WriteString("\n")
+		b.WriteString(m.theme.err.Render(tr("Error: %v", m.restoreErr)))
+	}
+
+	return b.String()
+}
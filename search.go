@@ -0,0 +1,161 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	gloss "github.com/charmbracelet/lipgloss"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// fuzzyMatch reports whether the characters of pattern appear in s in
+// order, ignoring case, how well they match and the rune positions in s
+// they matched at. Consecutive matches and matches at the start of a word
+// score higher, and gaps between matches score lower.
+func fuzzyMatch(pattern, s string) (score int, positions []int, ok bool) {
+	p := []rune(strings.ToLower(pattern))
+	r := []rune(strings.ToLower(s))
+
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	j, last := 0, -1
+
+	for i := 0; i < len(r) && j < len(p); i++ {
+		if r[i] != p[j] {
+			continue
+		}
+
+		score++
+		switch {
+		case last == i-1:
+			score += 5
+		case last >= 0:
+			score -= min(i-last-1, 3)
+		}
+		if i == 0 || !unicode.IsLetter(r[i-1]) && !unicode.IsDigit(r[i-1]) {
+			score += 3
+		}
+
+		positions = append(positions, i)
+		last = i
+		j++
+	}
+
+	return score, positions, j == len(p)
+}
+
+// matchItem scores how well the search query matches an item. Fuzzy
+// matching looks at the name and type; substring matching also looks at
+// the location and gives every match the same score.
+func (m model) matchItem(query string, item store.Item) (int, bool) {
+	if m.substringSearch {
+		for _, field := range []string{item.Name, item.WasteType, item.Location} {
+			if strings.Contains(strings.ToLower(field), query) {
+				return 0, true
+			}
+		}
+		return 0, false
+	}
+
+	nameScore, _, nameOK := fuzzyMatch(query, item.Name)
+	typeScore, _, typeOK := fuzzyMatch(query, item.WasteType)
+
+	switch {
+	case nameOK && typeOK:
+		return max(nameScore, typeScore), true
+	case nameOK:
+		return nameScore, true
+	case typeOK:
+		return typeScore, true
+	}
+
+	return 0, false
+}
+
+// searchPlaceholder names the active search mode.
+func (m model) searchPlaceholder() string {
+	if m.substringSearch {
+		return tr("Search (substring, ctrl+f for fuzzy)")
+	}
+	return tr("Search (fuzzy, ctrl+f for substring)")
+}
+
+// Columns the search looks at, by index into columnTitles.
+const (
+	nameColumn     = 0
+	typeColumn     = 1
+	locationColumn = 4
+)
+
+// matchPositions returns the rune positions in text, a cell of the given
+// column, that the search query matches, or nil if there are none.
+func (m model) matchPositions(column int, text string) []int {
+	query := strings.ToLower(m.search.Value())
+	if query == "" {
+		return nil
+	}
+
+	if !m.substringSearch {
+		if column != nameColumn && column != typeColumn {
+			return nil
+		}
+		_, positions, ok := fuzzyMatch(query, text)
+		if !ok {
+			return nil
+		}
+		return positions
+	}
+
+	if column != nameColumn && column != typeColumn && column != locationColumn {
+		return nil
+	}
+
+	var positions []int
+
+	r := []rune(strings.ToLower(text))
+	q := []rune(query)
+	for i := 0; i+len(q) <= len(r); i++ {
+		if string(r[i:i+len(q)]) == query {
+			for j := range q {
+				positions = append(positions, i+j)
+			}
+			i += len(q) - 1
+		}
+	}
+
+	return positions
+}
+
+// highlight renders text in style, underlining the runes at positions.
+func highlight(text string, positions []int, style gloss.Style) string {
+	if len(positions) == 0 {
+		return style.Render(text)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	var b strings.Builder
+
+	r := []rune(text)
+	for start := 0; start < len(r); {
+		end := start
+		for end < len(r) && matched[end] == matched[start] {
+			end++
+		}
+
+		if matched[start] {
+			b.WriteString(style.Underline(true).Render(string(r[start:end])))
+		} else {
+			b.WriteString(style.Render(string(r[start:end])))
+		}
+		start = end
+	}
+
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inForeground is set while a program run by foregroundCmd, such as the
+// notes editor, has the terminal.
+var inForeground atomic.Bool
+
+// quitOnSignal quits p when the terminal is closed or the program is told
+// to stop, so that the settings are saved and the database closed just as
+// when quitting with q. Changes to the database are made while handling a
+// message, so any transaction has finished by the time the quit is. A
+// second signal is not caught, and ends the program at once.
+//
+// Signals are ignored while another program has the terminal, as
+// bubbletea's own handler does, so that a ctrl+c meant for the editor
+// does not throw away the notes being written in it.
+func quitOnSignal(p *tea.Program) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	for range sig {
+		if inForeground.Load() {
+			continue
+		}
+
+		signal.Stop(sig)
+		p.Quit()
+		return
+	}
+}
+
+// foregroundCmd is a command to hand the terminal over to with tea.Exec,
+// which sets inForeground while it runs.
+type foregroundCmd struct {
+	*exec.Cmd
+}
+
+func (c foregroundCmd) Run() error {
+	inForeground.Store(true)
+	defer inForeground.Store(false)
+	return c.Cmd.Run()
+}
+
+// SetStdin, SetStdout and SetStderr give the command the terminal, as
+// tea.ExecProcess does, unless it has been given something else.
+func (c foregroundCmd) SetStdin(r io.Reader) {
+	if c.Stdin == nil {
+		c.Stdin = r
+	}
+}
+
+func (c foregroundCmd) SetStdout(w io.Writer) {
+	if c.Stdout == nil {
+		c.Stdout = w
+	}
+}
+
+func (c foregroundCmd) SetStderr(w io.Writer) {
+	if c.Stderr == nil {
+		c.Stderr = w
+	}
+}
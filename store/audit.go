@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"os/user"
+	"time"
+)
+
+// actor is who changes are recorded as having been made by.
+var actor = currentUser()
+
+// currentUser returns the login name of the user running the program, or
+// $USER if it cannot be looked up.
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// audit records action on the item with the given id in the audit log,
+// with a snapshot of the item as it is in tx.
+func (s *SQLite) audit(ctx context.Context, tx *sql.Tx, action string, id int) error {
+	item, err := scanItem(tx.StmtContext(ctx, s.stmts.snapshot).QueryRowContext(ctx, id))
+	if err == sql.ErrNoRows {
+		// Nothing changed, so there is nothing to record.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.stmts.audit).ExecContext(ctx, time.Now(), action, id, actor, string(snapshot))
+	return err
+}
+
+func (s *SQLite) AuditLog(limit int) ([]AuditEntry, error) {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, "SELECT id, at, action, item_id, actor, snapshot FROM audit_log ORDER BY id DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	defer rows.Close()
+
+	var entries []AuditEntry
+
+	for rows.Next() {
+		var e AuditEntry
+		if err := rows.Scan(&e.ID, &e.At, &e.Action, &e.ItemID, &e.Actor, &e.Snapshot); err != nil {
+			return nil, wrapErr(err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, wrapErr(rows.Err())
+}
@@ -0,0 +1,233 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migration changes the schema from one version to the next.
+type migration func(ctx context.Context, tx *sql.Tx) error
+
+// migrations are applied in order, each bringing the schema to the version
+// after its index. Never edit or reorder one that has shipped; append a
+// new one instead.
+var migrations = []migration{
+	createTables,
+	addLegacyColumns,
+	createAuditLog,
+	addVersion,
+	addFrequency,
+	addTags,
+	addIndexes,
+}
+
+// migrate applies the migrations the database has not had yet, each in a
+// transaction of its own together with the bump of schema_version, so
+// that a failed upgrade leaves the database at the last good version.
+func migrate(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("error creating schema_version table: %v", err)
+	}
+
+	version, err := schemaVersion(ctx, db)
+	if err != nil {
+		return fmt.Errorf("error reading schema version: %v", err)
+	}
+
+	if version > len(migrations) {
+		return fmt.Errorf("database schema is version %d, newer than this program's %d", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		err := withTx(ctx, db, func(tx *sql.Tx) error {
+			if err := migrations[i](ctx, tx); err != nil {
+				return err
+			}
+
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_version (version) VALUES (?)", i+1)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d: %v", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// schemaVersion returns the number of migrations the database has had,
+// which is zero for one without a schema_version table.
+func schemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var exists bool
+	err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_version')").Scan(&exists)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var version int
+	err = db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+	return version, err
+}
+
+// createTables is migration 1. Databases from before schema_version
+// already have waste_items, possibly without the later columns, which
+// addLegacyColumns adds.
+func createTables(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS waste_items (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT,
+		quantity REAL,
+		unit TEXT NOT NULL DEFAULT '',
+		wasteType TEXT,
+		location TEXT,
+		method TEXT,
+		created_at TIMESTAMP,
+		updated_at TIMESTAMP,
+		disposal_date TEXT NOT NULL DEFAULT '',
+		hazardous INTEGER NOT NULL DEFAULT 0,
+		deleted_at TIMESTAMP,
+		notes TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'collected' CHECK (status IN ('collected', 'pending', 'disposed')),
+		cost REAL NOT NULL DEFAULT 0
+	)`)
+	if err != nil {
+		return fmt.Errorf("error creating table: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS settings (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("error creating settings table: %v", err)
+	}
+
+	return nil
+}
+
+// addLegacyColumns is migration 2. It adds the columns that were added
+// with ALTER TABLE before there was a schema version, for databases that
+// are missing some of them.
+func addLegacyColumns(ctx context.Context, tx *sql.Tx) error {
+	for _, column := range []string{"created_at", "updated_at"} {
+		added, err := addColumn(ctx, tx, column, "TIMESTAMP")
+		if err != nil {
+			return err
+		}
+
+		if added {
+			_, err = tx.ExecContext(ctx, "UPDATE waste_items SET "+column+" = CURRENT_TIMESTAMP WHERE "+column+" IS NULL")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	columns := []struct{ name, decl string }{
+		{"unit", "TEXT NOT NULL DEFAULT ''"},
+		{"disposal_date", "TEXT NOT NULL DEFAULT ''"},
+		{"hazardous", "INTEGER NOT NULL DEFAULT 0"},
+		{"deleted_at", "TIMESTAMP"},
+		{"notes", "TEXT NOT NULL DEFAULT ''"},
+		{"status", "TEXT NOT NULL DEFAULT 'collected' CHECK (status IN ('collected', 'pending', 'disposed'))"},
+		{"cost", "REAL NOT NULL DEFAULT 0"},
+	}
+
+	for _, column := range columns {
+		if _, err := addColumn(ctx, tx, column.name, column.decl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createAuditLog is migration 3.
+func createAuditLog(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE audit_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		at TIMESTAMP NOT NULL,
+		action TEXT NOT NULL CHECK (action IN ('insert', 'update', 'delete', 'restore', 'purge')),
+		item_id INTEGER NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		snapshot TEXT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("error creating audit_log table: %v", err)
+	}
+
+	return nil
+}
+
+// addVersion is migration 4. Existing items start at version 1, as new
+// ones do.
+func addVersion(ctx context.Context, tx *sql.Tx) error {
+	_, err := addColumn(ctx, tx, "version", "INTEGER NOT NULL DEFAULT 1")
+	return err
+}
+
+// addFrequency is migration 5.
+func addFrequency(ctx context.Context, tx *sql.Tx) error {
+	_, err := addColumn(ctx, tx, "frequency", "TEXT NOT NULL DEFAULT '' CHECK (frequency IN ('', 'daily', 'weekly', 'monthly', 'quarterly', 'yearly'))")
+	return err
+}
+
+// addTags is migration 6. Tags are stored comma-separated.
+func addTags(ctx context.Context, tx *sql.Tx) error {
+	_, err := addColumn(ctx, tx, "tags", "TEXT NOT NULL DEFAULT ''")
+	return err
+}
+
+// addIndexes is migration 7. It indexes the columns the list is most
+// often filtered and sorted on.
+func addIndexes(ctx context.Context, tx *sql.Tx) error {
+	for _, column := range []string{"wasteType", "location"} {
+		_, err := tx.ExecContext(ctx, "CREATE INDEX IF NOT EXISTS waste_items_"+column+" ON waste_items ("+column+")")
+		if err != nil {
+			return fmt.Errorf("error indexing %s: %v", column, err)
+		}
+	}
+
+	return nil
+}
+
+// addColumn adds column to waste_items unless it already exists, and
+// reports whether it was added.
+func addColumn(ctx context.Context, tx *sql.Tx, column, decl string) (bool, error) {
+	rows, err := tx.QueryContext(ctx, "PRAGMA table_info(waste_items)")
+	if err != nil {
+		return false, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, typ        string
+			dflt             sql.NullString
+		)
+
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return false, err
+		}
+
+		if name == column {
+			return false, nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	rows.Close()
+
+	_, err = tx.ExecContext(ctx, "ALTER TABLE waste_items ADD COLUMN "+column+" "+decl)
+	return err == nil, err
+}
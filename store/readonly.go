@@ -0,0 +1,65 @@
+package store
+
+import "errors"
+
+// ErrReadOnly is returned by the methods that change items when the store
+// was opened read-only.
+var ErrReadOnly = errors.New("the database is open read-only")
+
+// readOnly wraps a Store so that items can be read but not changed.
+// Settings are not saved either, but without an error, so that the view
+// is simply not carried over to the next session.
+type readOnly struct {
+	Store
+}
+
+// ReadOnly returns s wrapped so that every method that changes items
+// returns ErrReadOnly instead.
+func ReadOnly(s Store) Store {
+	return readOnly{s}
+}
+
+func (readOnly) Add(Item) (Item, error) {
+	return Item{}, ErrReadOnly
+}
+
+func (readOnly) AddAll([]Item) ([]Item, error) {
+	return nil, ErrReadOnly
+}
+
+func (readOnly) Upsert([]Item) error {
+	return ErrReadOnly
+}
+
+func (readOnly) Update(Item) (Item, error) {
+	return Item{}, ErrReadOnly
+}
+
+func (readOnly) Delete(int) error {
+	return ErrReadOnly
+}
+
+func (readOnly) DeleteAll([]int) error {
+	return ErrReadOnly
+}
+
+func (readOnly) Restore(int) error {
+	return ErrReadOnly
+}
+
+func (readOnly) Purge(int) error {
+	return ErrReadOnly
+}
+
+func (readOnly) Clear() error {
+	return ErrReadOnly
+}
+
+// SetSetting drops the setting without an error, unlike the methods that
+// change items. Settings are only the view, such as the sort order, theme
+// and filters, which the TUI saves on every change and on quit; an error
+// for each would bury the read-only notice under ones the user can do
+// nothing about, and the view simply starts afresh next session.
+func (readOnly) SetSetting(string, string) error {
+	return nil
+}
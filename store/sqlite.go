@@ -0,0 +1,632 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// callTimeout bounds how long a single database call may take, so that a
+// hung database surfaces as an error instead of freezing the TUI.
+const callTimeout = 5 * time.Second
+
+// SQLite is a Store backed by an SQLite database file.
+type SQLite struct {
+	db    *sql.DB
+	stmts statements
+}
+
+// withTimeout returns a context that expires after callTimeout.
+func withTimeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), callTimeout)
+}
+
+// wrapErr turns a timed out call into a readable error.
+func wrapErr(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("database did not respond within %v", callTimeout)
+	}
+	return err
+}
+
+// ErrCorrupt is returned by Open when the file is not a database, or is a
+// damaged one.
+var ErrCorrupt = errors.New("database file is damaged or not a database")
+
+// Memory is the path that opens a fresh database held in memory. Nothing
+// written to it survives Close.
+const Memory = ":memory:"
+
+// Open opens the database at path and makes sure its schema exists.
+func Open(path string) (*SQLite, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %v", err)
+	}
+
+	// Pragmas apply per connection, so keep a single one open. This also
+	// keeps an in-memory database alive, as each connection to :memory:
+	// would get its own empty one.
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	if err := checkIntegrity(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := setPragmas(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error migrating database: %v", wrapErr(err))
+	}
+
+	s := &SQLite{db: db}
+	if err := s.stmts.prepare(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error preparing statements: %v", wrapErr(err))
+	}
+
+	return s, nil
+}
+
+// OpenReadOnly opens the database at path without writing to it: the file
+// is opened read-only, and neither migrated nor switched to WAL, so it has
+// to exist and be at the current schema version already. The store it
+// returns refuses changes with ErrReadOnly. An in-memory database has no
+// file to protect, and is opened as by Open.
+func OpenReadOnly(path string) (Store, error) {
+	if path == Memory {
+		s, err := Open(path)
+		if err != nil {
+			return nil, err
+		}
+		return ReadOnly(s), nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("error opening database: %v", err)
+	}
+
+	dsn := (&url.URL{Scheme: "file", Path: path, RawQuery: "mode=ro"}).String()
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %v", err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	if err := checkIntegrity(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if _, err := db.ExecContext(ctx, "PRAGMA busy_timeout=5000"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error setting busy timeout: %v", wrapErr(err))
+	}
+
+	version, err := schemaVersion(ctx, db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error reading schema version: %v", wrapErr(err))
+	}
+	if version != len(migrations) {
+		db.Close()
+		return nil, fmt.Errorf("database schema is version %d, not this program's %d, so it cannot be opened read-only; open it once without -read-only to upgrade it", version, len(migrations))
+	}
+
+	s := &SQLite{db: db}
+	if err := s.stmts.prepare(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error preparing statements: %v", wrapErr(err))
+	}
+
+	return ReadOnly(s), nil
+}
+
+func (s *SQLite) Close() error {
+	return errors.Join(s.stmts.close(), s.db.Close())
+}
+
+func (s *SQLite) Load() ([]Item, error) {
+	return s.load("deleted_at IS NULL")
+}
+
+func (s *SQLite) LoadDeleted() ([]Item, error) {
+	return s.load("deleted_at IS NOT NULL ORDER BY deleted_at DESC")
+}
+
+// load returns the items matching the where clause.
+func (s *SQLite) load(where string) ([]Item, error) {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+itemColumns+" FROM waste_items WHERE "+where)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	defer rows.Close()
+
+	var items []Item
+
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, wrapErr(rows.Err())
+}
+
+// itemColumns are the columns scanItem reads, in order.
+const itemColumns = "id, name, quantity, unit, wasteType, location, method, created_at, updated_at, disposal_date, hazardous, deleted_at, notes, status, cost, version, frequency, tags"
+
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanItem reads an item from a row of itemColumns.
+func scanItem(row scanner) (Item, error) {
+	var item Item
+	var deletedAt sql.NullTime
+	var tags string
+
+	err := row.Scan(&item.ID, &item.Name, &item.Quantity, &item.Unit, &item.WasteType, &item.Location, &item.Method,
+		&item.CreatedAt, &item.UpdatedAt, &item.DisposalDate, &item.Hazardous, &deletedAt, &item.Notes, &item.Status, &item.Cost, &item.Version, &item.Frequency, &tags)
+	item.DeletedAt = deletedAt.Time
+	item.Tags = ParseTags(tags)
+
+	return item, err
+}
+
+func (s *SQLite) Add(item Item) (Item, error) {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		var err error
+		item, err = s.insert(ctx, tx, item)
+		return err
+	})
+
+	return item, wrapErr(err)
+}
+
+func (s *SQLite) AddAll(items []Item) ([]Item, error) {
+	added := make([]Item, 0, len(items))
+
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		for _, item := range items {
+			item, err := s.insert(ctx, tx, item)
+			if err != nil {
+				return err
+			}
+			added = append(added, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return added, nil
+}
+
+func (s *SQLite) Upsert(items []Item) error {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		for _, item := range items {
+			if item.ID == 0 {
+				if _, err := s.insert(ctx, tx, item); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if item.CreatedAt.IsZero() {
+				item.CreatedAt = time.Now()
+			}
+			if item.Status == "" {
+				item.Status = StatusCollected
+			}
+			if item.UpdatedAt.IsZero() {
+				item.UpdatedAt = item.CreatedAt
+			}
+
+			var exists bool
+			if err := tx.StmtContext(ctx, s.stmts.exists).QueryRowContext(ctx, item.ID).Scan(&exists); err != nil {
+				return err
+			}
+
+			_, err := tx.StmtContext(ctx, s.stmts.upsert).ExecContext(ctx,
+				item.ID, item.Name, item.Quantity, item.Unit, item.WasteType, item.Location, item.Method, item.CreatedAt, item.UpdatedAt, item.DisposalDate, item.Hazardous, nullTime(item.DeletedAt), item.Notes, item.Status, item.Cost, item.Frequency, joinTags(item.Tags))
+			if err != nil {
+				return err
+			}
+
+			action := AuditInsert
+			if exists {
+				action = AuditUpdate
+			}
+			if err := s.audit(ctx, tx, action, item.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return wrapErr(err)
+}
+
+func (s *SQLite) Update(item Item) (Item, error) {
+	item.UpdatedAt = time.Now()
+	item.Tags = ParseTags(joinTags(item.Tags))
+
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		result, err := tx.StmtContext(ctx, s.stmts.update).ExecContext(ctx,
+			item.Name, item.Quantity, item.Unit, item.WasteType, item.Location, item.Method, item.UpdatedAt, item.DisposalDate, item.Hazardous, item.Notes, item.Status, item.Cost, item.Frequency, joinTags(item.Tags), item.ID, item.Version)
+		if err != nil {
+			return err
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrConflict
+		}
+
+		return s.audit(ctx, tx, AuditUpdate, item.ID)
+	})
+	if err != nil {
+		return item, wrapErr(err)
+	}
+
+	item.Version++
+	return item, nil
+}
+
+func (s *SQLite) Delete(id int) error {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		return s.softDelete(ctx, tx, id, time.Now())
+	})
+
+	return wrapErr(err)
+}
+
+func (s *SQLite) DeleteAll(ids []int) error {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	now := time.Now()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		for _, id := range ids {
+			if err := s.softDelete(ctx, tx, id, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return wrapErr(err)
+}
+
+func (s *SQLite) Restore(id int) error {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE waste_items SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", id)
+		if err != nil {
+			return err
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrNotFound
+		}
+
+		return s.audit(ctx, tx, AuditRestore, id)
+	})
+
+	return wrapErr(err)
+}
+
+func (s *SQLite) Purge(id int) error {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		// The snapshot is taken first, while the row still exists.
+		if err := s.audit(ctx, tx, AuditPurge, id); err != nil {
+			return err
+		}
+
+		result, err := tx.StmtContext(ctx, s.stmts.purge).ExecContext(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// Returning an error rolls back the audit entry along with it.
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrNotFound
+		}
+
+		return nil
+	})
+
+	return wrapErr(err)
+}
+
+func (s *SQLite) Clear() error {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, "SELECT id FROM waste_items")
+		if err != nil {
+			return err
+		}
+
+		var ids []int
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := s.audit(ctx, tx, AuditPurge, id); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM waste_items")
+		return err
+	})
+
+	return wrapErr(err)
+}
+
+func (s *SQLite) Setting(key string) (string, bool, error) {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	var value string
+
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM settings WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, wrapErr(err)
+	}
+
+	return value, true, nil
+}
+
+func (s *SQLite) SetSetting(key, value string) error {
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, "INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value",
+		key, value)
+	return wrapErr(err)
+}
+
+func (s *SQLite) Backup(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	ctx, cancel := withTimeout()
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, "VACUUM INTO ?", path)
+	return wrapErr(err)
+}
+
+// joinTags normalizes tags as ParseTags does and joins them for the tags
+// column.
+func joinTags(tags []string) string {
+	return strings.Join(ParseTags(strings.Join(tags, ",")), ",")
+}
+
+// nullTime stores the zero time as NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// withTx runs fn inside a transaction, committing if it succeeds and
+// rolling back if it returns an error.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLite) insert(ctx context.Context, tx *sql.Tx, item Item) (Item, error) {
+	now := time.Now()
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = now
+	}
+	item.UpdatedAt = now
+	if item.Status == "" {
+		item.Status = StatusCollected
+	}
+	item.Tags = ParseTags(joinTags(item.Tags))
+
+	result, err := tx.StmtContext(ctx, s.stmts.insert).ExecContext(ctx,
+		item.Name, item.Quantity, item.Unit, item.WasteType, item.Location, item.Method, item.CreatedAt, item.UpdatedAt, item.DisposalDate, item.Hazardous, item.Notes, item.Status, item.Cost, item.Frequency, joinTags(item.Tags))
+	if err != nil {
+		return item, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return item, err
+	}
+
+	item.ID = int(id)
+	item.Version = 1
+
+	return item, s.audit(ctx, tx, AuditInsert, item.ID)
+}
+
+// softDelete moves the item with the given id to the trash.
+func (s *SQLite) softDelete(ctx context.Context, tx *sql.Tx, id int, now time.Time) error {
+	if _, err := tx.StmtContext(ctx, s.stmts.softDelete).ExecContext(ctx, now, id); err != nil {
+		return err
+	}
+
+	return s.audit(ctx, tx, AuditDelete, id)
+}
+
+// checkIntegrity runs a quick consistency check of the database, so that
+// a damaged file is reported as ErrCorrupt up front rather than as a
+// cryptic error from whichever query first runs into the damage.
+func checkIntegrity(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, "PRAGMA quick_check")
+	if err != nil {
+		return corruptErr(err)
+	}
+
+	defer rows.Close()
+
+	var problems []string
+
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return corruptErr(err)
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return corruptErr(err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrCorrupt, problems[0])
+	}
+
+	return nil
+}
+
+// corruptErr wraps err in ErrCorrupt if SQLite reports a damaged file or
+// one that is not a database.
+func corruptErr(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB) {
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	return fmt.Errorf("error checking database: %v", wrapErr(err))
+}
+
+// setPragmas enables WAL journaling and a busy timeout so that other
+// processes can read the database while the TUI has it open. It also
+// turns on foreign keys, which SQLite leaves off unless asked, so that
+// tables added later can declare them. None does yet: audit_log.item_id
+// is deliberately not one, as the log outlives the items it records.
+func setPragmas(ctx context.Context, db *sql.DB) error {
+	var mode string
+	if err := db.QueryRowContext(ctx, "PRAGMA journal_mode=WAL").Scan(&mode); err != nil {
+		return fmt.Errorf("error setting journal mode: %v", wrapErr(err))
+	}
+
+	// In-memory databases cannot use WAL and always report "memory".
+	if !strings.EqualFold(mode, "wal") && !strings.EqualFold(mode, "memory") {
+		return fmt.Errorf("error setting journal mode: database is in %s mode", mode)
+	}
+
+	if _, err := db.ExecContext(ctx, "PRAGMA busy_timeout=5000"); err != nil {
+		return fmt.Errorf("error setting busy timeout: %v", wrapErr(err))
+	}
+
+	var timeout int
+	if err := db.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&timeout); err != nil {
+		return fmt.Errorf("error reading busy timeout: %v", wrapErr(err))
+	}
+
+	if timeout != 5000 {
+		return fmt.Errorf("error setting busy timeout: timeout is %dms", timeout)
+	}
+
+	if _, err := db.ExecContext(ctx, "PRAGMA foreign_keys=ON"); err != nil {
+		return fmt.Errorf("error enabling foreign keys: %v", wrapErr(err))
+	}
+
+	var foreignKeys bool
+	if err := db.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		return fmt.Errorf("error reading foreign keys: %v", wrapErr(err))
+	}
+
+	if !foreignKeys {
+		return fmt.Errorf("error enabling foreign keys: this SQLite does not support them")
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// openMemory opens a fresh in-memory database, closed when t ends.
+func openMemory(t *testing.T) *SQLite {
+	t.Helper()
+
+	s, err := Open(Memory)
+	if err != nil {
+		t.Fatalf("Open(Memory): %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestSQLiteRoundTrip(t *testing.T) {
+	s := openMemory(t)
+
+	added, err := s.Add(Item{
+		Name:         "Solvent",
+		Quantity:     12.5,
+		Unit:         "L",
+		WasteType:    "Chemical",
+		Location:     "Bay 3",
+		Method:       "Contractor",
+		DisposalDate: "2026-11-01",
+		Hazardous:    true,
+		Notes:        "Keep sealed",
+		Cost:         40,
+		Frequency:    FrequencyMonthly,
+		Tags:         []string{"flammable"},
+	})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if added.ID == 0 {
+		t.Fatal("Add returned an item without an id")
+	}
+
+	items, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Load returned %d items, want 1", len(items))
+	}
+
+	got := items[0]
+	if got.ID != added.ID || got.Name != "Solvent" || got.Quantity != 12.5 || got.Unit != "L" ||
+		got.WasteType != "Chemical" || got.Location != "Bay 3" || got.Method != "Contractor" ||
+		got.DisposalDate != "2026-11-01" || !got.Hazardous || got.Notes != "Keep sealed" ||
+		got.Cost != 40 || got.Frequency != FrequencyMonthly || len(got.Tags) != 1 || got.Tags[0] != "flammable" {
+		t.Errorf("Load returned %+v, want the item added", got)
+	}
+	if got.Status != StatusCollected {
+		t.Errorf("Status = %q, want %q", got.Status, StatusCollected)
+	}
+
+	stale := got
+	got.Quantity = 20
+	updated, err := s.Update(got)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Version != stale.Version+1 {
+		t.Errorf("Version after Update = %d, want %d", updated.Version, stale.Version+1)
+	}
+
+	stale.Name = "Stale"
+	if _, err := s.Update(stale); !errors.Is(err, ErrConflict) {
+		t.Errorf("Update at a stale version returned %v, want ErrConflict", err)
+	}
+
+	items, err = s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(items) != 1 || items[0].Quantity != 20 || items[0].Name != "Solvent" {
+		t.Errorf("Load after Update returned %+v, want quantity 20 and the name unchanged", items)
+	}
+
+	if err := s.Delete(added.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	items, err = s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("Load after Delete returned %d items, want 0", len(items))
+	}
+
+	deleted, err := s.LoadDeleted()
+	if err != nil {
+		t.Fatalf("LoadDeleted: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != added.ID {
+		t.Errorf("LoadDeleted returned %+v, want the deleted item", deleted)
+	}
+}
+
+func TestOpenReadOnlyLeavesFileAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waste.db")
+
+	if _, err := OpenReadOnly(path); err == nil {
+		t.Error("OpenReadOnly opened a missing file")
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Fatal("OpenReadOnly created the missing file")
+	}
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := s.Add(Item{Name: "Solvent", Quantity: 1}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ro, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+
+	items, err := ro.Load()
+	if err != nil || len(items) != 1 {
+		t.Errorf("Load returned %d items and %v, want 1 item", len(items), err)
+	}
+	if _, err := ro.Add(Item{Name: "Rags"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Add returned %v, want ErrReadOnly", err)
+	}
+	if err := ro.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("opening read-only changed the file")
+	}
+}
+
+func TestPurgeOutsideTheTrash(t *testing.T) {
+	s := openMemory(t)
+
+	item, err := s.Add(Item{Name: "Solvent", Quantity: 1})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	before, err := s.AuditLog(100)
+	if err != nil {
+		t.Fatalf("AuditLog: %v", err)
+	}
+
+	for _, id := range []int{item.ID, item.ID + 1} {
+		if err := s.Purge(id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Purge(%d) returned %v, want ErrNotFound", id, err)
+		}
+	}
+
+	after, err := s.AuditLog(100)
+	if err != nil {
+		t.Fatalf("AuditLog: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Errorf("failed purges added %d audit entries, want none", len(after)-len(before))
+	}
+
+	items, err := s.Load()
+	if err != nil || len(items) != 1 {
+		t.Errorf("Load returned %d items and %v, want the item untouched", len(items), err)
+	}
+
+	if err := s.Delete(item.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Purge(item.ID); err != nil {
+		t.Errorf("Purge of a trashed item: %v", err)
+	}
+}
+
+func TestRestoreOutsideTheTrash(t *testing.T) {
+	s := openMemory(t)
+
+	item, err := s.Add(Item{Name: "Solvent", Quantity: 1})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	before, err := s.AuditLog(100)
+	if err != nil {
+		t.Fatalf("AuditLog: %v", err)
+	}
+
+	// Neither an item outside the trash nor a purged one or one that never
+	// existed can be restored.
+	if err := s.Restore(item.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Restore of an item not in the trash returned %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(item.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Purge(item.ID); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+
+	afterPurge, err := s.AuditLog(100)
+	if err != nil {
+		t.Fatalf("AuditLog: %v", err)
+	}
+
+	for _, id := range []int{item.ID, item.ID + 1} {
+		if err := s.Restore(id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Restore(%d) returned %v, want ErrNotFound", id, err)
+		}
+	}
+
+	after, err := s.AuditLog(100)
+	if err != nil {
+		t.Fatalf("AuditLog: %v", err)
+	}
+	if len(afterPurge) != len(before)+2 || len(after) != len(afterPurge) {
+		t.Errorf("audit entries went %d, %d, %d, want only the delete and purge recorded", len(before), len(afterPurge), len(after))
+	}
+}
+
+// benchItems is how many items the benchmarks load, enough for the
+// indexes on type and location to matter.
+const benchItems = 20000
+
+// openBench opens an in-memory database holding benchItems items spread
+// over a few dozen types and locations.
+func openBench(b *testing.B) *SQLite {
+	b.Helper()
+
+	s, err := Open(Memory)
+	if err != nil {
+		b.Fatalf("Open(Memory): %v", err)
+	}
+	b.Cleanup(func() { s.Close() })
+
+	items := make([]Item, benchItems)
+	for i := range items {
+		items[i] = Item{
+			Name:      fmt.Sprintf("Item %d", i),
+			Quantity:  float64(i%100 + 1),
+			Unit:      "kg",
+			WasteType: fmt.Sprintf("Type %d", i%37),
+			Location:  fmt.Sprintf("Location %d", i%53),
+			Method:    "Landfill",
+		}
+	}
+	if _, err := s.AddAll(items); err != nil {
+		b.Fatalf("AddAll: %v", err)
+	}
+
+	b.ResetTimer()
+	return s
+}
+
+func BenchmarkLoad(b *testing.B) {
+	s := openBench(b)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Load(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoadSortedByType(b *testing.B) {
+	s := openBench(b)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.load("deleted_at IS NULL ORDER BY wasteType"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoadOneLocation(b *testing.B) {
+	s := openBench(b)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.load("deleted_at IS NULL AND location = 'Location 7'"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
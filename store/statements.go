@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// The statements behind the changes made most often, which are prepared
+// once when the database is opened rather than on every call.
+const (
+	insertQuery = "INSERT INTO waste_items (name, quantity, unit, wasteType, location, method, created_at, updated_at, disposal_date, hazardous, notes, status, cost, frequency, tags) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+
+	upsertQuery = "INSERT INTO waste_items (id, name, quantity, unit, wasteType, location, method, created_at, updated_at, disposal_date, hazardous, deleted_at, notes, status, cost, frequency, tags) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET name = excluded.name, quantity = excluded.quantity, unit = excluded.unit, wasteType = excluded.wasteType, location = excluded.location, method = excluded.method, created_at = excluded.created_at, updated_at = excluded.updated_at, disposal_date = excluded.disposal_date, hazardous = excluded.hazardous, deleted_at = excluded.deleted_at, notes = excluded.notes, status = excluded.status, cost = excluded.cost, frequency = excluded.frequency, tags = excluded.tags, version = version + 1"
+
+	updateQuery = "UPDATE waste_items SET name = ?, quantity = ?, unit = ?, wasteType = ?, location = ?, method = ?, updated_at = ?, disposal_date = ?, hazardous = ?, notes = ?, status = ?, cost = ?, frequency = ?, tags = ?, version = version + 1 WHERE id = ? AND version = ?"
+
+	existsQuery = "SELECT EXISTS (SELECT 1 FROM waste_items WHERE id = ?)"
+
+	softDeleteQuery = "UPDATE waste_items SET deleted_at = ? WHERE id = ?"
+
+	purgeQuery = "DELETE FROM waste_items WHERE id = ? AND deleted_at IS NOT NULL"
+
+	snapshotQuery = "SELECT " + itemColumns + " FROM waste_items WHERE id = ?"
+
+	auditQuery = "INSERT INTO audit_log (at, action, item_id, actor, snapshot) VALUES (?, ?, ?, ?, ?)"
+)
+
+// statements holds the prepared statements. Inside a transaction they are
+// used through tx.StmtContext, which reuses them on the transaction's
+// connection.
+type statements struct {
+	insert     *sql.Stmt
+	upsert     *sql.Stmt
+	update     *sql.Stmt
+	exists     *sql.Stmt
+	softDelete *sql.Stmt
+	purge      *sql.Stmt
+	snapshot   *sql.Stmt
+	audit      *sql.Stmt
+}
+
+// prepare prepares every statement, closing those already prepared if one
+// fails.
+func (s *statements) prepare(ctx context.Context, db *sql.DB) error {
+	for _, p := range s.all() {
+		stmt, err := db.PrepareContext(ctx, p.query)
+		if err != nil {
+			s.close()
+			return err
+		}
+		*p.stmt = stmt
+	}
+
+	return nil
+}
+
+// close closes the statements that have been prepared.
+func (s *statements) close() error {
+	var errs []error
+
+	for _, p := range s.all() {
+		if *p.stmt != nil {
+			errs = append(errs, (*p.stmt).Close())
+			*p.stmt = nil
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// all pairs each statement with its query.
+func (s *statements) all() []struct {
+	stmt  **sql.Stmt
+	query string
+} {
+	return []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		{&s.insert, insertQuery},
+		{&s.upsert, upsertQuery},
+		{&s.update, updateQuery},
+		{&s.exists, existsQuery},
+		{&s.softDelete, softDeleteQuery},
+		{&s.purge, purgeQuery},
+		{&s.snapshot, snapshotQuery},
+		{&s.audit, auditQuery},
+	}
+}
@@ -0,0 +1,273 @@
+// Package store persists waste items.
+package store
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrConflict is returned by Update when the item was changed by someone
+// else since it was loaded.
+var ErrConflict = errors.New("item changed since you loaded it, please refresh")
+
+// ErrNotFound is returned by Restore and Purge when there is no item with
+// the id in the trash.
+var ErrNotFound = errors.New("item is not in the trash")
+
+// DateLayout is the format of dates entered and stored as text.
+const DateLayout = "2006-01-02"
+
+// Statuses are the stages an item moves through, in order.
+var Statuses = []string{StatusCollected, StatusPending, StatusDisposed}
+
+const (
+	StatusCollected = "collected"
+	StatusPending   = "pending"
+	StatusDisposed  = "disposed"
+)
+
+// NextStatus returns the status that follows status, wrapping around after
+// the last one.
+func NextStatus(status string) string {
+	for i, s := range Statuses {
+		if s == status {
+			return Statuses[(i+1)%len(Statuses)]
+		}
+	}
+	return Statuses[0]
+}
+
+// ValidStatus reports whether status is one of Statuses.
+func ValidStatus(status string) bool {
+	for _, s := range Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Frequencies are how often a recurring item is collected. Items without
+// one are collected once.
+var Frequencies = []string{FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyQuarterly, FrequencyYearly}
+
+const (
+	FrequencyDaily     = "daily"
+	FrequencyWeekly    = "weekly"
+	FrequencyMonthly   = "monthly"
+	FrequencyQuarterly = "quarterly"
+	FrequencyYearly    = "yearly"
+)
+
+// ValidFrequency reports whether frequency is one of Frequencies or empty.
+func ValidFrequency(frequency string) bool {
+	if frequency == "" {
+		return true
+	}
+	for _, f := range Frequencies {
+		if f == frequency {
+			return true
+		}
+	}
+	return false
+}
+
+// Item is a single waste item.
+type Item struct {
+	ID        int       `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	Quantity  float64   `json:"quantity"`
+	Unit      string    `json:"unit"`
+	WasteType string    `json:"wasteType"`
+	Location  string    `json:"location"`
+	Method    string    `json:"method"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// DisposalDate is the target disposal date as YYYY-MM-DD, or empty.
+	DisposalDate string `json:"disposal_date"`
+
+	Hazardous bool `json:"hazardous"`
+
+	// Notes is free text about the item, such as why or how it is to be
+	// disposed of.
+	Notes string `json:"notes"`
+
+	// Status is one of Statuses. Items saved without one are collected.
+	Status string `json:"status"`
+
+	// Cost is what disposing of the item costs, or zero if unknown.
+	Cost float64 `json:"cost"`
+
+	// DeletedAt is when the item was moved to the trash, or zero if it
+	// has not been.
+	DeletedAt time.Time `json:"deleted_at"`
+
+	// Version counts the updates to the item, so that Update can tell
+	// whether it was changed since it was loaded.
+	Version int `json:"version"`
+
+	// Frequency is one of Frequencies for an item collected regularly,
+	// or empty for one collected once.
+	Frequency string `json:"frequency"`
+
+	// Tags are free-form labels, trimmed and lower-cased, in the order
+	// they were entered.
+	Tags []string `json:"tags"`
+}
+
+// ParseTags splits a comma-separated list of tags, trimming and
+// lower-casing each and dropping empty and repeated ones.
+func ParseTags(s string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// HasTag reports whether the item is tagged with tag.
+func (item Item) HasTag(tag string) bool {
+	for _, t := range item.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Overdue reports whether the item's disposal date is before today.
+func (item Item) Overdue(now time.Time) bool {
+	if item.DisposalDate == "" {
+		return false
+	}
+
+	date, err := time.ParseInLocation(DateLayout, item.DisposalDate, now.Location())
+	if err != nil {
+		return false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return date.Before(today)
+}
+
+// NextCollection returns when a recurring item is next due for
+// collection, one frequency interval after it was created. It returns the
+// zero time for an item without a frequency.
+func (item Item) NextCollection() time.Time {
+	switch item.Frequency {
+	case FrequencyDaily:
+		return item.CreatedAt.AddDate(0, 0, 1)
+	case FrequencyWeekly:
+		return item.CreatedAt.AddDate(0, 0, 7)
+	case FrequencyMonthly:
+		return item.CreatedAt.AddDate(0, 1, 0)
+	case FrequencyQuarterly:
+		return item.CreatedAt.AddDate(0, 3, 0)
+	case FrequencyYearly:
+		return item.CreatedAt.AddDate(1, 0, 0)
+	}
+	return time.Time{}
+}
+
+// Due reports whether a recurring item is due for collection again.
+func (item Item) Due(now time.Time) bool {
+	next := item.NextCollection()
+	return !next.IsZero() && !now.Before(next)
+}
+
+// Actions recorded in the audit log.
+const (
+	AuditInsert  = "insert"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// AuditEntry records one change to an item.
+type AuditEntry struct {
+	ID     int
+	At     time.Time
+	Action string
+	ItemID int
+
+	// Actor is the name of the user the change was made as.
+	Actor string
+
+	// Snapshot is the item as JSON, as it was after the change, or
+	// before it for a purge.
+	Snapshot string
+}
+
+// Store loads and saves waste items and settings.
+type Store interface {
+	// Load returns every item that is not in the trash.
+	Load() ([]Item, error)
+
+	// LoadDeleted returns the items in the trash, most recently deleted
+	// first.
+	LoadDeleted() ([]Item, error)
+
+	// Add inserts item and returns it with its id and timestamps set.
+	Add(item Item) (Item, error)
+
+	// AddAll inserts items in a single transaction, so that either all
+	// of them are added or none are.
+	AddAll(items []Item) ([]Item, error)
+
+	// Upsert saves items in a single transaction. Items with an id
+	// replace the stored item with that id, or are inserted under it if
+	// there is none; items without one are inserted as new.
+	Upsert(items []Item) error
+
+	// Update saves item and returns it with its updated timestamp and
+	// next version set. It returns ErrConflict and saves nothing if the
+	// stored item is no longer at item's version.
+	Update(item Item) (Item, error)
+
+	// Delete moves the item with the given id to the trash.
+	Delete(id int) error
+
+	// DeleteAll moves the items with the given ids to the trash in a
+	// single transaction.
+	DeleteAll(ids []int) error
+
+	// Restore takes the item with the given id back out of the trash. It
+	// returns ErrNotFound and records nothing if the item is not in the
+	// trash.
+	Restore(id int) error
+
+	// Purge permanently removes the item with the given id from the
+	// trash. It returns ErrNotFound and records nothing if the item is
+	// not in the trash.
+	Purge(id int) error
+
+	// AuditLog returns up to limit audit entries, most recent first.
+	AuditLog(limit int) ([]AuditEntry, error)
+
+	// Clear permanently removes every item, including those in the
+	// trash, in a single transaction.
+	Clear() error
+
+	// Setting returns the value stored under key, and whether there was one.
+	Setting(key string) (string, bool, error)
+
+	// SetSetting stores value under key, replacing any previous value.
+	SetSetting(key, value string) error
+
+	// Backup writes a consistent copy of the database to path, which
+	// must not exist yet.
+	Backup(path string) error
+
+	Close() error
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	gloss "github.com/charmbracelet/lipgloss"
+)
+
+var noStyle = gloss.NewStyle()
+
+// theme is a named set of styles that the whole interface is drawn with.
+type theme struct {
+	name string
+
+	focused        gloss.Style
+	blurred        gloss.Style
+	cursorModeHelp gloss.Style
+	title          gloss.Style
+	selected       gloss.Style
+	marked         gloss.Style
+	added          gloss.Style
+	err            gloss.Style
+	status         gloss.Style
+	detail         gloss.Style
+	label          gloss.Style
+	tag            gloss.Style
+
+	// typeColors maps lower-cased waste types to the color their rows
+	// are drawn in, and itemStatusColors maps item statuses to the color
+	// of their status cell. Anything not listed uses the default style.
+	typeColors       map[string]gloss.Color
+	itemStatusColors map[string]gloss.Color
+}
+
+// themes are the themes ctrl+t cycles through, starting with the default.
+var themes = []theme{
+	{
+		name:           "default",
+		focused:        gloss.NewStyle().Foreground(gloss.Color("205")),
+		blurred:        gloss.NewStyle().Foreground(gloss.Color("240")),
+		cursorModeHelp: gloss.NewStyle().Foreground(gloss.Color("244")),
+		title: gloss.NewStyle().
+			Bold(true).
+			Foreground(gloss.Color("#FAFAFA")).
+			Background(gloss.Color("#7D56F4")).
+			Padding(0, 1),
+		selected: gloss.NewStyle().
+			Foreground(gloss.Color("#FFFFFF")).
+			Background(gloss.Color("#0000FF")),
+		marked: gloss.NewStyle().Bold(true).Foreground(gloss.Color("212")),
+		added:  gloss.NewStyle().Foreground(gloss.Color("#000000")).Background(gloss.Color("10")),
+		err:    gloss.NewStyle().Foreground(gloss.Color("9")),
+		status: gloss.NewStyle().Foreground(gloss.Color("10")),
+		detail: gloss.NewStyle().
+			Border(gloss.RoundedBorder()).
+			BorderForeground(gloss.Color("#7D56F4")).
+			Padding(0, 1),
+		label: gloss.NewStyle().Bold(true),
+		tag: gloss.NewStyle().
+			Foreground(gloss.Color("#FAFAFA")).
+			Background(gloss.Color("62")).
+			Padding(0, 1),
+		typeColors: map[string]gloss.Color{
+			"plastic":    gloss.Color("33"),
+			"paper":      gloss.Color("180"),
+			"glass":      gloss.Color("51"),
+			"metal":      gloss.Color("250"),
+			"organic":    gloss.Color("70"),
+			"hazardous":  gloss.Color("208"),
+			"electronic": gloss.Color("141"),
+			"textile":    gloss.Color("175"),
+		},
+		itemStatusColors: map[string]gloss.Color{
+			"collected": gloss.Color("39"),
+			"pending":   gloss.Color("214"),
+			"disposed":  gloss.Color("42"),
+		},
+	},
+	{
+		name:           "solarized",
+		focused:        gloss.NewStyle().Foreground(gloss.Color("#268BD2")),
+		blurred:        gloss.NewStyle().Foreground(gloss.Color("#586E75")),
+		cursorModeHelp: gloss.NewStyle().Foreground(gloss.Color("#657B83")),
+		title: gloss.NewStyle().
+			Bold(true).
+			Foreground(gloss.Color("#FDF6E3")).
+			Background(gloss.Color("#073642")).
+			Padding(0, 1),
+		selected: gloss.NewStyle().
+			Foreground(gloss.Color("#002B36")).
+			Background(gloss.Color("#B58900")),
+		marked: gloss.NewStyle().Bold(true).Foreground(gloss.Color("#D33682")),
+		added:  gloss.NewStyle().Foreground(gloss.Color("#002B36")).Background(gloss.Color("#859900")),
+		err:    gloss.NewStyle().Foreground(gloss.Color("#DC322F")),
+		status: gloss.NewStyle().Foreground(gloss.Color("#859900")),
+		detail: gloss.NewStyle().
+			Border(gloss.RoundedBorder()).
+			BorderForeground(gloss.Color("#2AA198")).
+			Padding(0, 1),
+		label: gloss.NewStyle().Bold(true).Foreground(gloss.Color("#93A1A1")),
+		tag: gloss.NewStyle().
+			Foreground(gloss.Color("#FDF6E3")).
+			Background(gloss.Color("#2AA198")).
+			Padding(0, 1),
+		typeColors: map[string]gloss.Color{
+			"plastic":    gloss.Color("#268BD2"),
+			"paper":      gloss.Color("#B58900"),
+			"glass":      gloss.Color("#2AA198"),
+			"metal":      gloss.Color("#93A1A1"),
+			"organic":    gloss.Color("#859900"),
+			"hazardous":  gloss.Color("#CB4B16"),
+			"electronic": gloss.Color("#6C71C4"),
+			"textile":    gloss.Color("#D33682"),
+		},
+		itemStatusColors: map[string]gloss.Color{
+			"collected": gloss.Color("#268BD2"),
+			"pending":   gloss.Color("#B58900"),
+			"disposed":  gloss.Color("#859900"),
+		},
+	},
+	{
+		name:           "mono",
+		focused:        gloss.NewStyle().Bold(true),
+		blurred:        gloss.NewStyle().Faint(true),
+		cursorModeHelp: gloss.NewStyle().Faint(true),
+		title:          gloss.NewStyle().Bold(true).Reverse(true).Padding(0, 1),
+		selected:       gloss.NewStyle().Reverse(true),
+		marked:         gloss.NewStyle().Bold(true),
+		added:          gloss.NewStyle().Underline(true),
+		err:            gloss.NewStyle().Bold(true),
+		status:         gloss.NewStyle().Italic(true),
+		detail:         gloss.NewStyle().Border(gloss.RoundedBorder()).Padding(0, 1),
+		label:          gloss.NewStyle().Bold(true),
+		tag:            gloss.NewStyle().Reverse(true).Padding(0, 1),
+	},
+	plainTheme,
+}
+
+// plainTheme has no colors or attributes, for terminals that cannot show
+// them. Padding and borders are kept so that the layout does not change,
+// and the selected row is shown in reverse video so that the cursor is
+// still visible.
+var plainTheme = theme{
+	name:     "plain",
+	title:    gloss.NewStyle().Padding(0, 1),
+	selected: gloss.NewStyle().Reverse(true),
+	detail:   gloss.NewStyle().Border(gloss.RoundedBorder()).Padding(0, 1),
+	tag:      gloss.NewStyle().Padding(0, 1),
+}
+
+// themeByName returns the theme called name, and whether there is one.
+func themeByName(name string) (theme, bool) {
+	for _, t := range themes {
+		if t.name == name {
+			return t, true
+		}
+	}
+	return themes[0], false
+}
+
+// nextTheme returns the theme after t, wrapping around after the last.
+func nextTheme(t theme) theme {
+	for i := range themes {
+		if themes[i].name == t.name {
+			return themes[(i+1)%len(themes)]
+		}
+	}
+	return themes[0]
+}
+
+// help is the style of help text.
+func (t theme) help() gloss.Style {
+	return t.blurred
+}
+
+// button renders the form's submit button.
+func (t theme) button(focused bool) string {
+	if focused {
+		return t.focused.Render(tr("[Submit]"))
+	}
+	return fmt.Sprintf("[ %s ]", t.blurred.Render(tr("Submit")))
+}
+
+// typeStyle returns the row style for a waste type.
+func (t theme) typeStyle(wasteType string) gloss.Style {
+	color, ok := t.typeColors[strings.ToLower(strings.TrimSpace(wasteType))]
+	if !ok {
+		return noStyle
+	}
+	return gloss.NewStyle().Foreground(color)
+}
+
+// itemStatusStyle returns the style of an item's status cell.
+func (t theme) itemStatusStyle(status string) gloss.Style {
+	color, ok := t.itemStatusColors[status]
+	if !ok {
+		return noStyle
+	}
+	return gloss.NewStyle().Foreground(color)
+}
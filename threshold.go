@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quantityRange is the range of quantities shown in the list, typed as a
+// comparison such as ">100" or "<=5", a range such as "10-50", or a single
+// quantity. The zero value shows every quantity.
+type quantityRange struct {
+	// expr is the range as typed, to show and save.
+	expr string
+
+	// min and max bound the range where hasMin and hasMax are set, and
+	// exclude the bound itself where minOpen and maxOpen are.
+	min, max         float64
+	hasMin, hasMax   bool
+	minOpen, maxOpen bool
+}
+
+// parseQuantityRange parses a quantity range. An empty expr is the range
+// of every quantity.
+func parseQuantityRange(expr string) (quantityRange, error) {
+	expr = strings.TrimSpace(expr)
+	r := quantityRange{expr: expr}
+
+	if expr == "" {
+		return r, nil
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		rest, ok := strings.CutPrefix(expr, op)
+		if !ok {
+			continue
+		}
+
+		n, err := parseBound(rest)
+		if err != nil {
+			return quantityRange{}, err
+		}
+
+		switch op {
+		case ">=", ">":
+			r.min, r.hasMin, r.minOpen = n, true, op == ">"
+		case "<=", "<":
+			r.max, r.hasMax, r.maxOpen = n, true, op == "<"
+		default:
+			r.min, r.max, r.hasMin, r.hasMax = n, n, true, true
+		}
+		return r, nil
+	}
+
+	low, high, ok := strings.Cut(expr, "-")
+	if !ok {
+		high = low
+	}
+
+	minimum, err := parseBound(low)
+	if err != nil {
+		return quantityRange{}, err
+	}
+	maximum, err := parseBound(high)
+	if err != nil {
+		return quantityRange{}, err
+	}
+	if minimum > maximum {
+		return quantityRange{}, errors.New(tr("a range must go from the smaller quantity to the larger, as in 10-50"))
+	}
+
+	r.min, r.max, r.hasMin, r.hasMax = minimum, maximum, true, true
+	return r, nil
+}
+
+// parseBound parses one end of a quantity range.
+func parseBound(s string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, errors.New(tr("expected a quantity, a comparison such as >100, or a range such as 10-50"))
+	}
+	return n, nil
+}
+
+// active reports whether r leaves any quantities out.
+func (r quantityRange) active() bool {
+	return r.hasMin || r.hasMax
+}
+
+// contains reports whether quantity is in r.
+func (r quantityRange) contains(quantity float64) bool {
+	if r.hasMin && (quantity < r.min || r.minOpen && quantity == r.min) {
+		return false
+	}
+	if r.hasMax && (quantity > r.max || r.maxOpen && quantity == r.max) {
+		return false
+	}
+	return true
+}
+
+// updateThreshold handles keys while the quantity range is typed. The list
+// is filtered as it is typed, whenever what has been typed so far parses.
+func (m model) updateThreshold(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		r, err := parseQuantityRange(m.threshold.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+
+		m.inputmode = normal
+		m.threshold.Blur()
+		m.threshold.SetValue(r.expr)
+		m.err = nil
+		m.applyQuantityRange(r)
+		return m, nil
+
+	case "esc":
+		m.inputmode = normal
+		m.threshold.Blur()
+		m.threshold.SetValue("")
+		m.err = nil
+		m.applyQuantityRange(quantityRange{})
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.threshold, cmd = m.threshold.Update(msg)
+
+	if r, err := parseQuantityRange(m.threshold.Value()); err == nil {
+		m.applyQuantityRange(r)
+	}
+
+	return m, cmd
+}
+
+// applyQuantityRange filters the list to the quantities in r.
+func (m *model) applyQuantityRange(r quantityRange) {
+	m.quantityRange = r
+	m.filtered = m.filterItems()
+	m.clampCursor()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openTrash loads the deleted items and shows them.
+func (m model) openTrash() (tea.Model, tea.Cmd) {
+	trash, err := m.store.LoadDeleted()
+	if err != nil {
+		m.err = fmt.Errorf(tr("failed to load trash: %v"), err)
+		return m, nil
+	}
+
+	m.trash = trash
+	m.trashCursor = 0
+	m.inputmode = viewingTrash
+
+	return m, nil
+}
+
+func (m model) updateTrash(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "esc", "T":
+		m.inputmode = normal
+
+	case "up", "k":
+		if m.trashCursor > 0 {
+			m.trashCursor--
+		}
+
+	case "down", "j":
+		if m.trashCursor < len(m.trash)-1 {
+			m.trashCursor++
+		}
+
+	case "r":
+		if len(m.trash) > 0 && !m.readOnly {
+			return m.restoreItem()
+		}
+
+	case "D":
+		if len(m.trash) > 0 && !m.readOnly {
+			m.inputmode = confirmingPurge
+		}
+	}
+
+	return m, nil
+}
+
+// restoreItem takes the selected item out of the trash and back into the
+// list.
+func (m model) restoreItem() (tea.Model, tea.Cmd) {
+	item := m.trash[m.trashCursor]
+
+	if err := m.store.Restore(item.ID); err != nil {
+		m.err = fmt.Errorf(tr("failed to restore item: %v"), err)
+		return m, nil
+	}
+
+	item.DeletedAt = time.Time{}
+	m.trash = append(m.trash[:m.trashCursor], m.trash[m.trashCursor+1:]...)
+	m.trashCursor = max(min(m.trashCursor, len(m.trash)-1), 0)
+
+	m.waste = append(m.waste, item)
+	m.filtered = m.filterItems()
+	m.setStatus(tr("Restored '%s'", item.Name))
+
+	return m, nil
+}
+
+func (m model) updateConfirmPurge(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.inputmode = viewingTrash
+
+	if msg.String() != "y" {
+		return m, nil
+	}
+
+	item := m.trash[m.trashCursor]
+
+	if err := m.store.Purge(item.ID); err != nil {
+		m.err = fmt.Errorf(tr("failed to purge item: %v"), err)
+		return m, nil
+	}
+
+	m.trash = append(m.trash[:m.trashCursor], m.trash[m.trashCursor+1:]...)
+	m.trashCursor = max(min(m.trashCursor, len(m.trash)-1), 0)
+
+	m.setStatus(tr("Permanently deleted '%s'", item.Name))
+
+	return m, nil
+}
+
+// trashView renders the deleted items with when each was deleted.
+func (m model) trashView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.title.Render(tr("Trash")))
+	b.WriteString("\n")
+
+	if len(m.trash) == 0 {
+		b.WriteString(m.theme.help().Render(tr("The trash is empty")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	titles := make([]string, len(columnTitles))
+	for i, title := range columnTitles {
+		titles[i] = tr(title)
+	}
+	b.WriteString(m.theme.title.Render(m.formatRow(titles) + " | " + tr("Deleted")))
+	b.WriteString("\n")
+
+	for i, item := range m.trash {
+		line := m.formatRow(m.rowCells(item)) + " | " + item.DeletedAt.Format("2006-01-02 15:04")
+
+		if i == m.trashCursor {
+			b.WriteString(m.theme.selected.Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
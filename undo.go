@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// defaultUndoDepth is how many changes can be undone, unless the config
+// file sets another number.
+const defaultUndoDepth = 50
+
+// changeKind is what a change on the undo stack did to the items.
+type changeKind int
+
+const (
+	changeAdd changeKind = iota
+	changeDelete
+	changeEdit
+)
+
+// change is a change to the items that can be undone, and then redone.
+// Added and deleted items are moved into and out of the trash by id, so
+// only the ids and names of items are needed for those. An edit keeps the
+// item from before and after, and undoing or redoing it saves one of them
+// over whichever version is stored by then.
+type change struct {
+	kind changeKind
+
+	// items are the items added or deleted, or for an edit the item as
+	// it was before.
+	items []store.Item
+
+	// edited is the item after an edit.
+	edited store.Item
+}
+
+// describe names the change for the status bar.
+func (c change) describe() string {
+	switch {
+	case c.kind == changeEdit:
+		return tr("editing '%s'", c.edited.Name)
+	case c.kind == changeAdd && len(c.items) == 1:
+		return tr("adding '%s'", c.items[0].Name)
+	case c.kind == changeAdd:
+		return tr("adding %d items", len(c.items))
+	case len(c.items) == 1:
+		return tr("deleting '%s'", c.items[0].Name)
+	default:
+		return tr("deleting %d items", len(c.items))
+	}
+}
+
+// record pushes c onto the undo stack, dropping the oldest change if the
+// stack is full, and forgets the changes that could be redone.
+func (m *model) record(c change) {
+	if m.undoDepth <= 0 {
+		return
+	}
+
+	m.undo = append(m.undo, c)
+	if len(m.undo) > m.undoDepth {
+		m.undo = m.undo[len(m.undo)-m.undoDepth:]
+	}
+	m.redo = nil
+}
+
+// forgetChanges empties the undo and redo stacks, for when the items they
+// refer to are gone.
+func (m *model) forgetChanges() {
+	m.undo = nil
+	m.redo = nil
+}
+
+// undoChange reverses the most recent change and moves it to the redo
+// stack.
+func (m model) undoChange() (tea.Model, tea.Cmd) {
+	if len(m.undo) == 0 {
+		m.setStatus(tr("Nothing to undo"))
+		return m, nil
+	}
+
+	// A change that fails to be undone is dropped, as it would only fail
+	// again, for example because its item has been purged since.
+	c := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+
+	if err := m.revert(c, true); err != nil {
+		m.err = fmt.Errorf(tr("failed to undo %s: %v"), c.describe(), err)
+		return m, nil
+	}
+
+	m.redo = append(m.redo, c)
+	m.setStatus(tr("Undid %s", c.describe()))
+
+	return m, nil
+}
+
+// redoChange makes the most recently undone change again and moves it
+// back to the undo stack.
+func (m model) redoChange() (tea.Model, tea.Cmd) {
+	if len(m.redo) == 0 {
+		m.setStatus(tr("Nothing to redo"))
+		return m, nil
+	}
+
+	c := m.redo[len(m.redo)-1]
+	m.redo = m.redo[:len(m.redo)-1]
+
+	if err := m.revert(c, false); err != nil {
+		m.err = fmt.Errorf(tr("failed to redo %s: %v"), c.describe(), err)
+		return m, nil
+	}
+
+	m.undo = append(m.undo, c)
+	m.setStatus(tr("Redid %s", c.describe()))
+
+	return m, nil
+}
+
+// revert undoes c, or redoes it if undo is false, in the store and in
+// m.waste, and moves the cursor to the item it changed.
+func (m *model) revert(c change, undo bool) error {
+	if c.kind == changeEdit {
+		target := c.edited
+		if undo {
+			target = c.items[0]
+		}
+		return m.saveOver(target)
+	}
+
+	ids := make([]int, len(c.items))
+	for i, item := range c.items {
+		ids[i] = item.ID
+	}
+
+	// Undoing an add and redoing a delete both move the items to the
+	// trash, and the other way round takes them back out of it.
+	if (c.kind == changeAdd) == undo {
+		if err := m.store.DeleteAll(ids); err != nil {
+			return err
+		}
+	} else {
+		for _, item := range c.items {
+			err := m.store.Restore(item.ID)
+			if errors.Is(err, store.ErrNotFound) {
+				return fmt.Errorf(tr("'%s' is no longer in the trash"), item.Name)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, id := range ids {
+		delete(m.selected, id)
+	}
+
+	if err := m.reload(); err != nil {
+		return err
+	}
+	m.selectID(ids[0])
+
+	return nil
+}
+
+// saveOver saves item over the stored version of the item with its id.
+func (m *model) saveOver(item store.Item) error {
+	for i, stored := range m.waste {
+		if stored.ID != item.ID {
+			continue
+		}
+
+		item.Version = stored.Version
+		item, err := m.store.Update(item)
+		if err != nil {
+			return err
+		}
+
+		m.waste[i] = item
+		m.filtered = m.filterItems()
+		m.selectID(item.ID)
+		return nil
+	}
+
+	return fmt.Errorf(tr("'%s' is no longer in the list"), item.Name)
+}
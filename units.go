@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// massUnits are the kilograms in one of each mass unit quantities may be
+// entered in.
+var massUnits = map[string]float64{
+	"g":   0.001,
+	"kg":  1,
+	"t":   1000,
+	"lb":  0.45359237,
+	"lbs": 0.45359237,
+}
+
+// massDisplays are the units mass quantities can be shown in, which U
+// cycles through. The first shows them as entered.
+var massDisplays = []string{"", "kg", "lb"}
+
+// nextMassDisplay returns the mass unit that follows unit in
+// massDisplays, wrapping around after the last one.
+func nextMassDisplay(unit string) string {
+	for i, u := range massDisplays {
+		if u == unit {
+			return massDisplays[(i+1)%len(massDisplays)]
+		}
+	}
+	return massDisplays[0]
+}
+
+// display returns item with its quantity converted to m.massUnit if it is
+// in a mass unit and one is chosen. Only the copy is converted, never the
+// stored item.
+func (m model) display(item store.Item) store.Item {
+	if m.massUnit == "" {
+		return item
+	}
+
+	perKg, ok := massUnits[strings.ToLower(strings.TrimSpace(item.Unit))]
+	if !ok {
+		return item
+	}
+
+	item.Quantity = item.Quantity * perKg / massUnits[m.massUnit]
+	item.Unit = m.massUnit
+	return item
+}
+
+// displayAll returns copies of items converted by display.
+func (m model) displayAll(items []store.Item) []store.Item {
+	converted := make([]store.Item, len(items))
+	for i, item := range items {
+		converted[i] = m.display(item)
+	}
+	return converted
+}
@@ -0,0 +1,797 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gloss "github.com/charmbracelet/lipgloss"
+
+	"github.com/shotoyaar/waste_management_tui/store"
+)
+
+// header renders the table header, marking the active sort column.
+func (m model) header() string {
+	titles := make([]string, len(columnTitles))
+
+	for i, title := range columnTitles {
+		title = tr(title)
+		if sortColumn(i+1) == m.sortColumn {
+			if m.sortDesc {
+				title += " ↓"
+			} else {
+				title += " ↑"
+			}
+		}
+		titles[i] = title
+	}
+
+	return m.formatRow(titles)
+}
+
+// rowCells returns the table cells for item, in column order, with its
+// quantity in the chosen mass unit.
+func (m model) rowCells(item store.Item) []string {
+	item = m.display(item)
+
+	name := item.Name
+	if item.Notes != "" {
+		name = "✎ " + name
+	}
+	if item.Due(time.Now()) {
+		name = "↻ " + name
+	}
+	if item.Hazardous {
+		name = "⚠ " + name
+	}
+
+	return []string{name, item.WasteType,
+		m.formatQuantity(item.Quantity), item.Unit, item.Location, item.Method,
+		item.CreatedAt.Format(store.DateLayout), item.DisposalDate, item.Status}
+}
+
+// columnWidths shares the terminal width between the shown table columns
+// in proportion to columnWeights. Hidden columns get no width.
+func (m model) columnWidths() []int {
+	widths := make([]int, len(columnWeights))
+
+	total, shown := 0, 0
+	for i, w := range columnWeights {
+		if !m.hiddenColumns[i] {
+			widths[i] = w
+			total += w
+			shown++
+		}
+	}
+
+	if m.width == 0 || shown == 0 {
+		return widths
+	}
+
+	// Leave room for the separators and the header's padding.
+	available := m.width - 3*(shown-1) - 2
+
+	for i, w := range columnWeights {
+		if !m.hiddenColumns[i] {
+			widths[i] = max(available*w/total, 3)
+		}
+	}
+
+	return widths
+}
+
+// formatRow pads or truncates each cell to its column width, leaving out
+// the hidden columns.
+func (m model) formatRow(cells []string) string {
+	widths := m.columnWidths()
+	var fitted []string
+
+	for i, cell := range cells {
+		if !m.hiddenColumns[i] {
+			fitted = append(fitted, fit(cell, widths[i]))
+		}
+	}
+
+	return strings.Join(fitted, " | ")
+}
+
+// renderRow renders the cells of a table row in style, except for the
+// status cell, which is drawn in statusStyle, and any search matches, which
+// are underlined.
+func (m model) renderRow(cells []string, style, statusStyle gloss.Style) string {
+	widths := m.columnWidths()
+	var parts []string
+
+	for i, cell := range cells {
+		if m.hiddenColumns[i] {
+			continue
+		}
+
+		text := fit(cell, widths[i])
+
+		cellStyle := style
+		if i == len(cells)-1 {
+			cellStyle = statusStyle
+		}
+
+		parts = append(parts, highlight(text, m.matchPositions(i, text), cellStyle))
+	}
+
+	return strings.Join(parts, style.Render(" | "))
+}
+
+// fit pads s to width, or truncates it with an ellipsis if it is longer.
+func fit(s string, width int) string {
+	r := []rune(s)
+
+	if len(r) > width {
+		if width <= 1 {
+			return string(r[:width])
+		}
+		return string(r[:width-1]) + "…"
+	}
+
+	return s + strings.Repeat(" ", width-len(r))
+}
+
+// statusKeys is how many key presses a status message stays on screen for.
+const statusKeys = 2
+
+// setStatus shows a transient success message and clears any error.
+func (m *model) setStatus(status string) {
+	m.err = nil
+	m.status = status
+	m.statusTTL = statusKeys
+}
+
+// statusBar renders the item count and the current status message.
+func (m model) statusBar() string {
+	count := tr("%d items", len(m.waste))
+	if len(m.filtered) != len(m.waste) {
+		count = tr("showing %d of %d", len(m.filtered), len(m.waste))
+	}
+	if len(m.selected) > 0 {
+		count += tr(", %d selected", len(m.selected))
+	}
+	if m.statusFilter != "" {
+		count += tr(" · %s only", m.statusFilter)
+	}
+	if m.tagFilter != "" {
+		count += tr(" · tagged %s", m.tagFilter)
+	}
+	if m.groupLocations {
+		count += tr(" · by location")
+	}
+	if m.quantityRange.active() {
+		count += tr(" · quantity %s", m.quantityRange.expr)
+	}
+	if m.createdWithin > 0 {
+		count += tr(" · created in the last %d days", m.createdWithin)
+	}
+	if m.jump != "" {
+		count += tr(" · go to row %s", m.jump)
+	}
+
+	if m.status == "" {
+		return m.theme.help().Render(count)
+	}
+
+	return m.theme.help().Render(count+" · ") + m.theme.status.Render(m.status)
+}
+
+// resetInputs sets the form back to its defaults and moves focus back to
+// the first field.
+func (m *model) resetInputs() {
+	for i := range m.inputs {
+		m.inputs[i].SetValue(m.defaults[i])
+	}
+	m.markOpened()
+
+	m.fieldErrs = nil
+	m.focusIndex = 0
+	m.focusInputs()
+}
+
+// reservedLines is roughly how many lines of the screen are taken up by
+// everything other than the table rows.
+const reservedLines = 13
+
+// rowsPerPage returns the configured page size, or as many rows as fit in
+// the window when none is set.
+func (m model) rowsPerPage() int {
+	if m.pageSize > 0 {
+		return m.pageSize
+	}
+
+	if m.height == 0 {
+		return 20
+	}
+
+	return max((m.height-reservedLines)/m.rowLines(), 1)
+}
+
+// Names the row density is saved under.
+const (
+	compactDensity     = "compact"
+	comfortableDensity = "comfortable"
+)
+
+// rowLines returns how many screen lines each table row takes up.
+func (m model) rowLines() int {
+	if m.comfortable {
+		return 2
+	}
+	return 1
+}
+
+// secondaryLine describes the notes, tags, cost and collection frequency
+// of item on one line, for beneath its row when the table is comfortable.
+// It is empty if item has none of them.
+func (m model) secondaryLine(item store.Item) string {
+	var parts []string
+
+	if notes := strings.Join(strings.Fields(item.Notes), " "); notes != "" {
+		parts = append(parts, "✎ "+notes)
+	}
+	if len(item.Tags) > 0 {
+		parts = append(parts, "#"+strings.Join(item.Tags, " #"))
+	}
+	if item.Cost != 0 {
+		parts = append(parts, m.formatCost(item.Cost))
+	}
+	if item.Frequency != "" {
+		parts = append(parts, nextCollection(item))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	line := "  " + strings.Join(parts, " · ")
+	if m.width > 0 {
+		line = strings.TrimRight(fit(line, m.width), " ")
+	}
+	return line
+}
+
+// tableTop returns the screen line of the first table row, below the
+// title, the hint line and the search bar when they are shown and the
+// table headings. It must be kept in step with View.
+func (m model) tableTop() int {
+	top := 4
+	if m.showHints() {
+		top++
+	}
+	if m.inputmode == searching || m.search.Value() != "" {
+		top += 2
+	}
+
+	return top
+}
+
+// visibleRows returns the range of filtered that fits on screen. It starts
+// at m.offset, moved as little as needed to keep the cursor in view, so
+// that the table scrolls a row at a time as the cursor moves. The screen
+// has room for rowsPerPage rows of lines, less one for each location
+// header when the table is grouped.
+//
+// A bubbles viewport would need every row rendered to scroll through, and
+// the cursor, mouse and headers mapped from its lines back to rows, where
+// this renders only the rows shown and works in rows throughout.
+func (m model) visibleRows() (start, end int) {
+	rows := m.rowsPerPage()
+	budget := rows * m.rowLines()
+
+	// Rows take up at least rowLines each, so the cursor is never more
+	// than rows below the first row shown.
+	start = max(m.offset, m.cursor-rows+1)
+	if m.cursor < start {
+		start = m.cursor
+	}
+	for start < m.cursor && m.tableLines(start, m.cursor+1) > budget {
+		start++
+	}
+
+	// Near the end of the list, rows are brought in from above to keep
+	// the screen full. No more than rows of them can fit, which saves
+	// counting the lines of the rest of a long list.
+	for start > 0 && len(m.filtered)-start < rows && m.tableLines(start-1, len(m.filtered)) <= budget {
+		start--
+	}
+
+	// The row at start is always shown, even when it alone needs more
+	// room than there is.
+	end = start
+	for lines := 0; end < len(m.filtered); end++ {
+		lines += m.rowLines()
+		if m.startsGroup(end, start) {
+			lines++
+		}
+		if lines > budget && end > start {
+			break
+		}
+	}
+
+	return start, end
+}
+
+// tableLines returns how many screen lines rows start to end of filtered
+// take up when start is the first row shown, location headers included.
+func (m model) tableLines(start, end int) int {
+	lines := 0
+	for i := start; i < end; i++ {
+		if m.startsGroup(i, start) {
+			lines++
+		}
+		lines += m.rowLines()
+	}
+	return lines
+}
+
+type typeTotal struct {
+	wasteType string
+	unit      string
+	quantity  float64
+	cost      float64
+}
+
+// typeTotals sums the quantity of items per waste type, largest first.
+// Quantities in different units are kept apart.
+func typeTotals(items []store.Item) []typeTotal {
+	var totals []typeTotal
+	index := make(map[[2]string]int)
+
+	for _, item := range items {
+		key := [2]string{item.WasteType, item.Unit}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(totals)
+			index[key] = i
+			totals = append(totals, typeTotal{wasteType: item.WasteType, unit: item.Unit})
+		}
+		totals[i].quantity += item.Quantity
+		totals[i].cost += item.Cost
+	}
+
+	sort.SliceStable(totals, func(i, j int) bool {
+		return totals[i].quantity > totals[j].quantity
+	})
+
+	return totals
+}
+
+// unitStats summarises the quantities of the items in one unit.
+type unitStats struct {
+	unit     string
+	count    int
+	sum      float64
+	smallest store.Item
+	largest  store.Item
+}
+
+// quantityStats summarises item quantities per unit, in the order the units
+// first appear, since quantities in different units cannot be compared.
+func quantityStats(items []store.Item) []unitStats {
+	var stats []unitStats
+	index := make(map[string]int)
+
+	for _, item := range items {
+		i, ok := index[item.Unit]
+		if !ok {
+			i = len(stats)
+			index[item.Unit] = i
+			stats = append(stats, unitStats{unit: item.Unit, smallest: item, largest: item})
+		}
+
+		st := &stats[i]
+		st.count++
+		st.sum += item.Quantity
+		if item.Quantity < st.smallest.Quantity {
+			st.smallest = item
+		}
+		if item.Quantity > st.largest.Quantity {
+			st.largest = item
+		}
+	}
+
+	return stats
+}
+
+// statsView renders the per-type totals panel followed by the item count
+// and the average, smallest and largest quantity in each unit.
+func (m model) statsView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.title.Render(tr("Totals by Type")))
+	b.WriteString("\n")
+
+	totals := typeTotals(m.displayAll(m.waste))
+
+	var units []string
+	grand := make(map[string]float64)
+
+	for _, t := range totals {
+		fmt.Fprintf(&b, "%-15s %10s %s\n", fit(t.wasteType, 15), m.formatQuantity(t.quantity), t.unit)
+
+		if _, ok := grand[t.unit]; !ok {
+			units = append(units, t.unit)
+		}
+		grand[t.unit] += t.quantity
+	}
+
+	for _, unit := range units {
+		fmt.Fprintf(&b, "%-15s %10s %s\n", tr("Total"), m.formatQuantity(grand[unit]), unit)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.theme.title.Render(tr("Cost by Type")))
+	b.WriteString("\n")
+
+	costs := make(map[string]float64)
+	var types []string
+	total := 0.0
+
+	for _, t := range totals {
+		if _, ok := costs[t.wasteType]; !ok {
+			types = append(types, t.wasteType)
+		}
+		costs[t.wasteType] += t.cost
+		total += t.cost
+	}
+
+	for _, wasteType := range types {
+		fmt.Fprintf(&b, "%-15s %10s\n", fit(wasteType, 15), m.formatCost(costs[wasteType]))
+	}
+	fmt.Fprintf(&b, "%-15s %10s\n", tr("Total"), m.formatCost(total))
+
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "%-15s %10d\n", tr("Items"), len(m.waste))
+
+	for _, st := range quantityStats(m.displayAll(m.waste)) {
+		fmt.Fprintf(&b, "%-15s %10s %s\n", tr("Average"), m.formatQuantity(st.sum/float64(st.count)), st.unit)
+		fmt.Fprintf(&b, "%-15s %10s %s (%s)\n", tr("Smallest"), m.formatQuantity(st.smallest.Quantity), st.unit, st.smallest.Name)
+		fmt.Fprintf(&b, "%-15s %10s %s (%s)\n", tr("Largest"), m.formatQuantity(st.largest.Quantity), st.unit, st.largest.Name)
+	}
+
+	return b.String()
+}
+
+func (m model) View() string {
+	var b strings.Builder
+
+	// Title
+	title := tr("Waste Management System")
+	if len(m.profiles) > 1 {
+		title += " · " + m.profiles[m.profile].name
+	}
+	if m.readOnly {
+		title += " · " + tr("read-only")
+	}
+	b.WriteString(m.theme.title.Render(title))
+	b.WriteString("\n")
+	if m.showHints() {
+		b.WriteString(m.hintLine())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// Unreadable database
+	if m.recoverable() {
+		b.WriteString(m.recoverView())
+		return b.String()
+	}
+
+	// Startup failure
+	if m.store == nil {
+		b.WriteString(m.theme.err.Render(tr("Error: %v", m.err)))
+		b.WriteString("\n\n")
+		b.WriteString(m.theme.help().Render(tr("Press (q) to quit")))
+		return b.String()
+	}
+
+	// Detail Card
+	if m.inputmode == viewingDetail {
+		b.WriteString(m.detailView())
+		b.WriteString("\n\n")
+		if m.readOnly {
+			b.WriteString(m.theme.help().Render(tr("Press (enter) or (esc) to return to the list")))
+		} else {
+			b.WriteString(m.theme.help().Render(tr("Press (ctrl+o) to edit the notes in your editor, (enter) or (esc) to return to the list")))
+		}
+		if m.err != nil {
+			b.WriteString("\n")
+			b.WriteString(m.theme.err.Render(tr("Error: %v", m.err)))
+		}
+		return b.String()
+	}
+
+	// Help Overlay
+	if m.inputmode == viewingHelp {
+		b.WriteString(m.helpView())
+		b.WriteString("\n\n")
+		b.WriteString(m.theme.help().Render(tr("Press any key to close")))
+		return b.String()
+	}
+
+	// Trash
+	if m.inputmode == viewingTrash || m.inputmode == confirmingPurge {
+		b.WriteString(m.trashView())
+		b.WriteString("\n")
+
+		if m.inputmode == confirmingPurge {
+			item := m.trash[m.trashCursor]
+			b.WriteString(m.theme.err.Render(tr("Permanently delete '%s'? This cannot be undone. (y/n)", item.Name)))
+		} else {
+			if m.readOnly {
+				b.WriteString(m.theme.help().Render(tr("Press up/down or j/k to move, (esc) or (T) to return")))
+			} else {
+				b.WriteString(m.theme.help().Render(tr("Press (r) to restore, (D) to delete permanently, up/down or j/k to move, (esc) or (T) to return")))
+			}
+		}
+
+		if m.err != nil {
+			b.WriteString("\n")
+			b.WriteString(m.theme.err.Render(tr("Error: %v", m.err)))
+		}
+		b.WriteString("\n")
+		b.WriteString(m.statusBar())
+		return b.String()
+	}
+
+	// Chart
+	if m.inputmode == viewingChart {
+		b.WriteString(m.chartView())
+		b.WriteString("\n")
+		b.WriteString(m.theme.help().Render(tr("Press (enter), (esc) or (C) to return to the list")))
+		return b.String()
+	}
+
+	// Label
+	if m.inputmode == viewingLabel {
+		b.WriteString(m.itemLabel(m.waste[m.current()]))
+		b.WriteString("\n")
+		b.WriteString(m.theme.help().Render(tr("Press (s) to save the label to a text file, (enter), (esc) or (l) to return to the list")))
+		if m.err != nil {
+			b.WriteString("\n")
+			b.WriteString(m.theme.err.Render(tr("Error: %v", m.err)))
+		}
+		return b.String()
+	}
+
+	// Duplicates Report
+	if m.inputmode == viewingDuplicates {
+		b.WriteString(m.duplicatesView())
+		b.WriteString("\n")
+		b.WriteString(m.theme.help().Render(tr("Press (enter), (esc) or (D) to return to the list")))
+		return b.String()
+	}
+
+	// Column Menu
+	if m.inputmode == choosingColumns {
+		b.WriteString(m.columnsView())
+		b.WriteString("\n")
+		b.WriteString(m.theme.help().Render(tr("Press (space) to show or hide the column, up/down or j/k to move, (esc) or (v) to return")))
+		return b.String()
+	}
+
+	// Audit Log
+	if m.inputmode == viewingAudit {
+		b.WriteString(m.auditView())
+		b.WriteString("\n")
+		b.WriteString(m.theme.help().Render(tr("Press up/down or j/k to move, [/] to page, (esc) or (A) to return")))
+
+		if m.err != nil {
+			b.WriteString("\n")
+			b.WriteString(m.theme.err.Render(tr("Error: %v", m.err)))
+		}
+		b.WriteString("\n")
+		b.WriteString(m.statusBar())
+		return b.String()
+	}
+
+	// Search Bar
+	if m.inputmode == searching || m.search.Value() != "" {
+		b.WriteString(m.search.View())
+		b.WriteString("\n\n")
+	}
+
+	// Waste Items Table
+	if len(m.filtered) > 0 {
+		title := tr("Current Waste Items")
+		if m.massUnit != "" {
+			title += tr(" · masses in %s", m.massUnit)
+		}
+		b.WriteString(m.theme.title.Render(title))
+		b.WriteString("\n")
+		b.WriteString(m.theme.title.Render(m.header()))
+		b.WriteString("\n")
+
+		now := time.Now()
+		start, end := m.visibleRows()
+
+		for i := start; i < end; i++ {
+			if header, ok := m.groupHeader(i, start); ok {
+				b.WriteString(m.theme.label.Render(header))
+				b.WriteString("\n")
+			}
+
+			item := m.waste[m.filtered[i]]
+			cells := m.rowCells(item)
+			if m.selected[item.ID] {
+				cells[0] = "● " + cells[0]
+			}
+
+			style := m.theme.typeStyle(item.WasteType)
+			statusStyle := m.theme.itemStatusStyle(item.Status)
+			if m.cursor == i && m.inputmode == normal {
+				style, statusStyle = m.theme.selected, m.theme.selected
+			} else if m.selected[item.ID] {
+				style = m.theme.marked
+			} else if m.added[item.ID] {
+				style, statusStyle = m.theme.added, m.theme.added
+			} else if item.Overdue(now) {
+				style = m.theme.err
+			}
+
+			b.WriteString(m.renderRow(cells, style, statusStyle))
+			b.WriteString("\n")
+
+			if m.comfortable {
+				b.WriteString(m.theme.help().Render(m.secondaryLine(item)))
+				b.WriteString("\n")
+			}
+		}
+
+		if start > 0 || end < len(m.filtered) {
+			b.WriteString(m.theme.help().Render(tr("Rows %d–%d of %d", start+1, end, len(m.filtered))))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	} else if len(m.waste) == 0 && m.store != nil && !m.inForm() {
+		empty := m.theme.label.Render(tr("No waste items yet"))
+		if !m.readOnly {
+			empty += tr(" — press (a) to add your first one, or (i) to import some")
+		}
+		b.WriteString(m.theme.detail.Render(empty))
+		b.WriteString("\n\n")
+	}
+
+	// Stats Panel
+	if m.showStats {
+		b.WriteString(m.statsView())
+		b.WriteString("\n")
+	}
+
+	// Delete Confirmation
+	if m.inputmode == confirmingDelete {
+		if len(m.selected) > 0 {
+			b.WriteString(m.theme.err.Render(tr("Move %d selected items to the trash? (y/n)", len(m.selected))))
+		} else {
+			item := m.waste[m.current()]
+			b.WriteString(m.theme.err.Render(tr("Move '%s' (%s) to the trash? (y/n)", item.Name, m.formatQuantity(item.Quantity))))
+		}
+		b.WriteString("\n\n")
+	}
+
+	// Merge Confirmation
+	if m.inputmode == confirmingMerge {
+		item := m.waste[m.duplicate]
+		b.WriteString(m.theme.err.Render(tr("Similar item exists: '%s' (%s %s) — merge quantities or add anyway?", item.Name, m.formatQuantity(item.Quantity), item.Unit)))
+		b.WriteString("\n\n")
+	}
+
+	// Quit Confirmation
+	if m.inputmode == confirmingQuit {
+		b.WriteString(m.theme.err.Render(tr("Discard unsaved changes? (y/n)")))
+		b.WriteString("\n\n")
+	}
+
+	// Clear Confirmation
+	if m.inputmode == confirmingClear {
+		b.WriteString(m.theme.err.Render(tr("This permanently removes every item, %d in the list and any in the trash.", len(m.waste))))
+		b.WriteString("\n")
+		b.WriteString(m.clearConfirm.View())
+		b.WriteString("\n\n")
+	}
+
+	// Quantity Range Prompt
+	if m.inputmode == filteringQuantity {
+		b.WriteString(m.threshold.View())
+		b.WriteString("\n\n")
+	}
+
+	// Import Prompt
+	if m.inputmode == importing {
+		b.WriteString(m.importPath.View())
+		b.WriteString("\n\n")
+	}
+
+	// Input Fields
+	if m.inForm() {
+		if m.inputmode == editing {
+			b.WriteString(m.theme.title.Render(tr("Edit Waste Item")))
+		} else {
+			b.WriteString(m.theme.title.Render(tr("Add New Waste Item")))
+		}
+		b.WriteString("\n")
+
+		for i := range m.inputs {
+			b.WriteString(m.inputs[i].View())
+			if msg, ok := m.fieldErrs[i]; ok {
+				b.WriteString("\n  ")
+				b.WriteString(m.theme.err.Render(msg))
+			}
+			if i < len(m.inputs)-1 {
+				b.WriteRune('\n')
+			}
+		}
+
+		fmt.Fprintf(&b, "\n\n%s\n\n", m.theme.button(m.focusIndex == len(m.inputs)))
+	}
+
+	// Help Text
+	b.WriteString(m.theme.help().Render(tr("cursor mode is ")))
+	b.WriteString(m.theme.cursorModeHelp.Render(m.cursorMode.String()))
+	b.WriteString(m.theme.help().Render(tr(" (ctrl+r to change style)")))
+	b.WriteString("\n")
+
+	// Instructions
+	switch m.inputmode {
+	case confirmingDelete, confirmingQuit:
+		b.WriteString(m.theme.help().Render(tr("Press (y) to confirm, any other key to cancel")))
+	case confirmingMerge:
+		b.WriteString(m.theme.help().Render(tr("Press (m) to merge, (a) to add anyway, (esc) to return to the form")))
+	case searching:
+		b.WriteString(m.theme.help().Render(tr("Type to filter, (ctrl+f) to switch fuzzy/substring matching, (enter) to keep the filter, (esc) to clear")))
+	case importing:
+		b.WriteString(m.theme.help().Render(tr("Press (enter) to import the file, (esc) to cancel")))
+	case confirmingClear:
+		b.WriteString(m.theme.help().Render(tr("Press (enter) to confirm, (esc) to cancel")))
+	case filteringQuantity:
+		b.WriteString(m.theme.help().Render(tr("Type a comparison such as >100 or a range such as 10-50, (enter) to keep the filter, (esc) to clear")))
+	case normal:
+		if m.readOnly {
+			b.WriteString(m.theme.help().Render(tr("Press (enter) for details, (/) to search, (s/S) to sort, up/down or j/k to move, (?) for all keys, (q) to quit")))
+			break
+		}
+		b.WriteString(m.theme.help().Render(tr("Press (a) to add, (e) to edit, (enter) for details, (d) to delete, (/) to search, (s/S) to sort, up/down or j/k to move, (?) for all keys, (q) to quit")))
+	default:
+		b.WriteString(m.theme.help().Render(tr("Press (enter) to move to next field, tab/shift+tab to switch fields, (esc) to cancel")))
+	}
+
+	// Error display
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(m.theme.err.Render(tr("Error: %v", m.err)))
+	}
+
+	// Status Bar
+	b.WriteString("\n")
+	b.WriteString(m.statusBar())
+
+	return b.String()
+}
+
+// func getInputModeName(mode inputmode) string {
+// 	switch mode {
+// 	case addingName:
+// 		return "waste name"
+
+// 	case addingQuantity:
+// 		return "quantity"
+
+// 	case addingWasteType:
+// 		return "waste type"
+
+// 	case addingLocation:
+// 		return "location"
+
+// 	case addingMethod:
+// 		return "disposal method"
+
+// 	default:
+// 		return ""
+// 	}
+// }